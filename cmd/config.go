@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the user defaults",
+	Long: `Manage the defaults in the user config file,
+$XDG_CONFIG_HOME/go-project-generator/config.yaml or
+~/.config/go-project-generator/config.yaml.
+
+The defaults apply to every flag that is not given on the command line and
+are overridden by project files given with --config.`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Print the value of a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, err := lookupDefaultsKey(args[0])
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), key.get(&defaults))
+		return err
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set [key] [value]",
+	Short: "Change a setting, an empty value removes it",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, err := lookupDefaultsKey(args[0])
+		if err != nil {
+			return err
+		}
+		if err := key.set(&defaults, args[1]); err != nil {
+			return fmt.Errorf("%s: %w", key.name, err)
+		}
+		return saveDefaults(defaults)
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Print every setting",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := defaultsPath()
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		_, _ = fmt.Fprintf(out, "# %s\n", path)
+		for _, key := range defaultsKeys {
+			_, _ = fmt.Fprintf(out, "%-14s %-24s # %s\n", key.name, key.get(&defaults), key.description)
+		}
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd, configSetCmd, configListCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestMain(m *testing.M) {
+	// Keep the developer's own defaults out of the tests.
+	dir, err := os.MkdirTemp("", "go-project-generator-config-")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.Setenv("XDG_CONFIG_HOME", dir); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// resetFlags restores the defaults of the persistent flags and forgets the
+// user defaults, which the commands under test leave behind.
+func resetFlags(t *testing.T) {
+	t.Cleanup(func() {
+		defaults = userDefaults{}
+		rootCmd.PersistentFlags().VisitAll(func(f *pflag.Flag) {
+			if slice, ok := f.Value.(pflag.SliceValue); ok {
+				_ = slice.Replace(nil)
+			} else {
+				_ = f.Value.Set(f.DefValue)
+			}
+			f.Changed = false
+		})
+	})
+}
+
+// executeRoot runs the root command with args and returns its output.
+func executeRoot(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	defer rootCmd.SetOut(nil)
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	return out.String(), err
+}
+
+func TestConfigCommand(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	resetFlags(t)
+
+	for _, args := range [][]string{
+		{"config", "set", "license", "isc"},
+		{"config", "set", "features", "docker, ci"},
+		{"config", "set", "git", "false"},
+	} {
+		if _, err := executeRoot(t, args...); err != nil {
+			t.Fatalf("%v: error = %v", args, err)
+		}
+	}
+
+	tests := map[string]string{
+		"license":  "ISC\n",
+		"features": "docker,ci\n",
+		"git":      "false\n",
+		"author":   "\n",
+	}
+	for key, want := range tests {
+		got, err := executeRoot(t, "config", "get", key)
+		if err != nil {
+			t.Fatalf("config get %s: error = %v", key, err)
+		}
+		if got != want {
+			t.Errorf("config get %s = %q, want %q", key, got, want)
+		}
+	}
+
+	list, err := executeRoot(t, "config", "list")
+	if err != nil {
+		t.Fatalf("config list: error = %v", err)
+	}
+	if !strings.Contains(list, "license") || !strings.Contains(list, "ISC") {
+		t.Errorf("config list = %q, want the license setting", list)
+	}
+
+	for _, args := range [][]string{
+		{"config", "set", "license", "GPL-3.0"},
+		{"config", "set", "router", "chi"},
+		{"config", "get", "router"},
+		{"config", "set", "git", "maybe"},
+	} {
+		if _, err := executeRoot(t, args...); err == nil {
+			t.Errorf("%v: error = nil, want error", args)
+		}
+	}
+}
+
+func TestDefaultsApplyUnderFlags(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	resetFlags(t)
+
+	configFile := filepath.Join(configHome, "go-project-generator", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(configFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "author: Our Org\nmodule-prefix: github.com/ourorg\nlicense: MIT\ngo: \"1.23\"\ngit: false\n"
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tempDir := t.TempDir()
+	if _, err := executeRoot(t, "cli", "my-cli", "--output", tempDir, "--license", "ISC"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	projectPath := filepath.Join(tempDir, "my-cli")
+	goMod, err := os.ReadFile(filepath.Join(projectPath, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(goMod), "module github.com/ourorg/my-cli\n\ngo 1.23\n") {
+		t.Errorf("go.mod = %q, want module github.com/ourorg/my-cli and go 1.23", goMod)
+	}
+
+	// The --license flag takes precedence over the defaults.
+	licenseText, err := os.ReadFile(filepath.Join(projectPath, "LICENSE"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(licenseText), "ISC License") || !strings.Contains(string(licenseText), "Our Org") {
+		t.Errorf("LICENSE = %q, want an ISC license for Our Org", licenseText)
+	}
+
+	if _, err := os.Stat(filepath.Join(projectPath, ".git")); err == nil {
+		t.Error("git repository was initialized, want git: false from the defaults")
+	}
+}
+
+func TestBrokenConfigFile(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	resetFlags(t)
+
+	configFile := filepath.Join(configHome, "go-project-generator", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(configFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeConfig := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tempDir := t.TempDir()
+
+	// Generating fails on unknown settings and invalid values, naming the file.
+	for content, want := range map[string]string{
+		"licence: MIT\n":            "field licence not found",
+		"license: GPL\n":            "license: unsupported license",
+		"features: [docker, k8s]\n": `features: unknown feature "k8s"`,
+		"go: latest\n":              "go: invalid Go version",
+	} {
+		writeConfig(content)
+		_, err := executeRoot(t, "cli", "my-cli", "--output", tempDir)
+		if err == nil || !strings.Contains(err.Error(), configFile) || !strings.Contains(err.Error(), want) {
+			t.Errorf("config %q: error = %v, want %q in %s", content, err, want, configFile)
+		}
+	}
+
+	// The config command still works, and repairs the file.
+	writeConfig("licence: MIT\nlicense: GPL\nauthor: Our Org\n")
+	if list, err := executeRoot(t, "config", "list"); err != nil || !strings.Contains(list, "GPL") {
+		t.Errorf("config list = %q, %v, want the invalid license listed", list, err)
+	}
+	if _, err := executeRoot(t, "config", "set", "license", "MIT"); err != nil {
+		t.Fatalf("config set: error = %v", err)
+	}
+	if _, err := executeRoot(t, "cli", "my-cli", "--output", tempDir, "--git=false"); err != nil {
+		t.Errorf("Execute() after the repair error = %v", err)
+	}
+	content, _ := os.ReadFile(configFile)
+	if string(content) != "author: Our Org\nlicense: MIT\n" {
+		t.Errorf("config file = %q, want author and license", content)
+	}
+}
+
+func TestDefaultFeaturesOfProjectType(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	resetFlags(t)
+
+	configFile := filepath.Join(configHome, "go-project-generator", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(configFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configFile, []byte("features: [docker, ci]\ngit: false\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Libraries do not support docker, so the defaults only select ci.
+	tempDir := t.TempDir()
+	if _, err := executeRoot(t, "library", "my-lib", "--output", tempDir); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	projectPath := filepath.Join(tempDir, "my-lib")
+	if _, err := os.Stat(filepath.Join(projectPath, ".github", "workflows", "ci.yml")); err != nil {
+		t.Errorf("ci workflow was not created: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "Dockerfile")); err == nil {
+		t.Error("Dockerfile was created for a library")
+	}
+}
+
+func TestResolveTemplateDir(t *testing.T) {
+	templates := t.TempDir()
+	if err := os.Mkdir(filepath.Join(templates, "service"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	defaults = userDefaults{Templates: []string{filepath.Join(t.TempDir(), "missing"), templates}}
+	t.Cleanup(func() { defaults = userDefaults{} })
+
+	tests := map[string]string{
+		"":                "",
+		"service":         filepath.Join(templates, "service"),
+		"unknown":         "unknown",
+		"./local/service": "./local/service",
+	}
+	for name, want := range tests {
+		if got := resolveTemplateDir(name); got != want {
+			t.Errorf("resolveTemplateDir(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Hassani-Jr/go-project-generator/internal/generator"
+)
+
+// errAborted is returned by the conflict prompt when the user quits.
+var errAborted = errors.New("aborted by user")
+
+// conflictMode returns the conflict mode selected by the command line flags.
+func conflictMode() generator.ConflictMode {
+	switch {
+	case force:
+		return generator.ConflictOverwrite
+	case skipExisting:
+		return generator.ConflictSkip
+	case merge:
+		return generator.ConflictAsk
+	default:
+		return generator.ConflictFail
+	}
+}
+
+// newConflictPrompt returns a conflict resolver that shows the diff of every
+// conflicting file on out and reads the decision from in.
+func newConflictPrompt(in io.Reader, out io.Writer) generator.ConflictResolver {
+	reader := bufio.NewReader(in)
+	overwriteAll := false
+
+	return func(c generator.Conflict) (bool, error) {
+		if overwriteAll {
+			return true, nil
+		}
+
+		_, _ = fmt.Fprintf(out, "\n%s already exists:\n%s", c.Path, c.Diff())
+		for {
+			_, _ = fmt.Fprintf(out, "Overwrite %s? [y]es, [n]o, [a]ll, [q]uit: ", c.Path)
+
+			answer, err := reader.ReadString('\n')
+			if err != nil && (err != io.EOF || answer == "") {
+				if err == io.EOF {
+					return false, errAborted
+				}
+				return false, err
+			}
+
+			switch strings.ToLower(strings.TrimSpace(answer)) {
+			case "y", "yes":
+				return true, nil
+			case "n", "no":
+				return false, nil
+			case "a", "all":
+				overwriteAll = true
+				return true, nil
+			case "q", "quit":
+				return false, errAborted
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Hassani-Jr/go-project-generator/internal/generator"
+)
+
+func TestConflictPrompt(t *testing.T) {
+	conflict := generator.Conflict{Path: "main.go", Existing: "old\n", Generated: "new\n"}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []bool
+		wantErr error
+	}{
+		{name: "yes", input: "y\n", want: []bool{true}},
+		{name: "no", input: "no\n", want: []bool{false}},
+		{name: "retry", input: "maybe\nY\n", want: []bool{true}},
+		{name: "all", input: "a\n", want: []bool{true, true, true}},
+		{name: "quit", input: "q\n", want: []bool{false}, wantErr: errAborted},
+		{name: "eof", input: "", want: []bool{false}, wantErr: errAborted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			prompt := newConflictPrompt(strings.NewReader(tt.input), &out)
+
+			for i, want := range tt.want {
+				got, err := prompt(conflict)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("prompt() error = %v, want %v", err, tt.wantErr)
+				}
+				if got != want {
+					t.Errorf("prompt() call %d = %v, want %v", i, got, want)
+				}
+			}
+
+			if !strings.Contains(out.String(), "-old\n+new\n") {
+				t.Errorf("prompt did not show the diff:\n%s", out.String())
+			}
+		})
+	}
+}
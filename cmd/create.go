@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Hassani-Jr/go-project-generator/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create [project-type] [project-name]",
+	Short: "Generate a project of the given type",
+	Long: `Generate a project of the given type. The project type may be any of the
+project subcommands or one of their aliases, for example:
+
+  go-project-generator create cli my-awesome-cli
+  go-project-generator create webservice user-api
+  go-project-generator create lib go-validator
+
+Options of the project type, which the project subcommands take as flags, are
+given with --option as name=value:
+
+  go-project-generator create web user-api --option db=sqlite --option middleware=logging,cors
+
+With --config, the project type and name default to the ones in the project
+file:
+
+  go-project-generator create --config project.yaml`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var (
+			projectType generator.ProjectType
+			projectName string
+			err         error
+		)
+		if len(args) > 0 {
+			if projectType, err = resolveProjectType(args[0]); err != nil {
+				return err
+			}
+		}
+		if len(args) > 1 {
+			projectName = args[1]
+		}
+
+		options, err := parseOptions(createOptions)
+		if err != nil {
+			return err
+		}
+		return generateProject(projectType, projectName, options)
+	},
+}
+
+// createOptions holds the --option values of the create command.
+var createOptions []string
+
+// parseOptions parses project type options given as name=value.
+func parseOptions(values []string) (map[string]string, error) {
+	options := make(map[string]string, len(values))
+	for _, option := range values {
+		name, value, ok := strings.Cut(option, "=")
+		if name = strings.TrimSpace(name); !ok || name == "" {
+			return nil, fmt.Errorf("invalid option %q: want name=value", option)
+		}
+		options[name] = value
+	}
+	return options, nil
+}
+
+// resolveProjectType finds the registered project type whose name or one of
+// whose aliases matches name, ignoring case.
+func resolveProjectType(name string) (generator.ProjectType, error) {
+	if projectType, ok := generator.Lookup(name); ok {
+		return projectType, nil
+	}
+
+	var known []string
+	for _, projectType := range generator.Types() {
+		known = append(known, projectType.Name())
+	}
+	return nil, fmt.Errorf("unknown project type %q (available: %s)", name, strings.Join(known, ", "))
+}
+
+func init() {
+	createCmd.Flags().StringArrayVar(&createOptions, "option", nil, "Project type option as name=value, e.g. db=sqlite (repeatable)")
+	rootCmd.AddCommand(createCmd)
+}
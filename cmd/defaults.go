@@ -0,0 +1,301 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Hassani-Jr/go-project-generator/internal/generator"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// userDefaults holds the defaults read from the user's config file. They
+// apply to every flag that is not given on the command line.
+type userDefaults struct {
+	Author       string   `yaml:"author,omitempty"`
+	ModulePrefix string   `yaml:"module-prefix,omitempty"`
+	License      string   `yaml:"license,omitempty"`
+	GoVersion    string   `yaml:"go,omitempty"`
+	Templates    []string `yaml:"templates,omitempty"`
+	Features     []string `yaml:"features,omitempty"`
+	Output       string   `yaml:"output,omitempty"`
+	Git          *bool    `yaml:"git,omitempty"`
+}
+
+// defaults are the user defaults loaded before any command runs.
+var defaults userDefaults
+
+// defaultsKey is a setting of the user config file managed by the config
+// command.
+type defaultsKey struct {
+	name        string
+	description string
+	get         func(d *userDefaults) string
+	// set validates value and stores it, an empty value clears the setting.
+	set func(d *userDefaults, value string) error
+}
+
+var defaultsKeys = []defaultsKey{
+	{
+		name:        "author",
+		description: "Author named in the license",
+		get:         func(d *userDefaults) string { return d.Author },
+		set: func(d *userDefaults, value string) error {
+			d.Author = value
+			return nil
+		},
+	},
+	{
+		name:        "module-prefix",
+		description: "Module path prefix, e.g. github.com/ourorg, the project's module segment is appended to",
+		get:         func(d *userDefaults) string { return d.ModulePrefix },
+		set: func(d *userDefaults, value string) error {
+			value = strings.TrimSuffix(value, "/")
+			if value != "" {
+				if err := generator.ValidateModulePath(value); err != nil {
+					return err
+				}
+			}
+			d.ModulePrefix = value
+			return nil
+		},
+	},
+	{
+		name:        "license",
+		description: "License of the project: " + strings.Join(generator.Licenses(), ", ") + " or " + generator.LicenseNone,
+		get:         func(d *userDefaults) string { return d.License },
+		set: func(d *userDefaults, value string) error {
+			license, err := generator.ValidateLicense(value)
+			d.License = license
+			return err
+		},
+	},
+	{
+		name:        "go",
+		description: "Go version declared in go.mod",
+		get:         func(d *userDefaults) string { return d.GoVersion },
+		set: func(d *userDefaults, value string) error {
+			d.GoVersion = value
+			return generator.ValidateGoVersion(value)
+		},
+	},
+	{
+		name:        "templates",
+		description: "Directories, comma separated, searched for --template names",
+		get:         func(d *userDefaults) string { return strings.Join(d.Templates, ",") },
+		set: func(d *userDefaults, value string) error {
+			d.Templates = splitList(value)
+			return nil
+		},
+	},
+	{
+		name:        "features",
+		description: "Optional features, comma separated: " + featureNames(generator.Features()),
+		get:         func(d *userDefaults) string { return strings.Join(d.Features, ",") },
+		set: func(d *userDefaults, value string) error {
+			d.Features = splitList(value)
+			return generator.ValidateFeatures(d.Features)
+		},
+	},
+	{
+		name:        "output",
+		description: "Output directory for projects",
+		get:         func(d *userDefaults) string { return d.Output },
+		set: func(d *userDefaults, value string) error {
+			d.Output = value
+			return nil
+		},
+	},
+	{
+		name:        "git",
+		description: "Initialize a git repository: true or false",
+		get: func(d *userDefaults) string {
+			if d.Git == nil {
+				return ""
+			}
+			return strconv.FormatBool(*d.Git)
+		},
+		set: func(d *userDefaults, value string) error {
+			if value == "" {
+				d.Git = nil
+				return nil
+			}
+			git, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid value %q: want true or false", value)
+			}
+			d.Git = &git
+			return nil
+		},
+	},
+}
+
+// lookupDefaultsKey returns the config file setting called name.
+func lookupDefaultsKey(name string) (defaultsKey, error) {
+	for _, key := range defaultsKeys {
+		if key.name == name {
+			return key, nil
+		}
+	}
+
+	var known []string
+	for _, key := range defaultsKeys {
+		known = append(known, key.name)
+	}
+	sort.Strings(known)
+	return defaultsKey{}, fmt.Errorf("unknown setting %q (available: %s)", name, strings.Join(known, ", "))
+}
+
+// defaultsPath returns the path of the user config file,
+// $XDG_CONFIG_HOME/go-project-generator/config.yaml or
+// ~/.config/go-project-generator/config.yaml.
+func defaultsPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "go-project-generator", "config.yaml"), nil
+}
+
+// loadDefaults reads the user config file. A missing file yields empty
+// defaults. If strict is set, unknown settings and invalid values are an
+// error, otherwise they are ignored and kept so that the config command can
+// repair the file.
+func loadDefaults(strict bool) (userDefaults, error) {
+	var d userDefaults
+
+	path, err := defaultsPath()
+	if err != nil {
+		return d, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return d, nil
+	}
+	if err != nil {
+		return d, err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(strict)
+	if err := dec.Decode(&d); err != nil && !errors.Is(err, io.EOF) {
+		return d, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if strict {
+		// Setting every value again applies the checks of config set.
+		for _, key := range defaultsKeys {
+			if err := key.set(&d, key.get(&d)); err != nil {
+				return d, fmt.Errorf("invalid config file %s: %s: %w", path, key.name, err)
+			}
+		}
+	}
+	return d, nil
+}
+
+// saveDefaults writes d to the user config file.
+func saveDefaults(d userDefaults) error {
+	path, err := defaultsPath()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(&d)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// applyDefaults loads the user defaults and applies them to the flags of cmd
+// that were not given on the command line. The config command loads them
+// leniently, so that it can repair a broken file.
+func applyDefaults(cmd *cobra.Command, args []string) error {
+	repair := cmd == configCmd || cmd.Parent() == configCmd
+	var err error
+	if defaults, err = loadDefaults(!repair); err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	unset := func(name string) bool {
+		flag := flags.Lookup(name)
+		return flag != nil && !flag.Changed
+	}
+
+	if defaults.Author != "" && unset("author") {
+		author = defaults.Author
+	}
+	if defaults.License != "" && unset("license") {
+		license = defaults.License
+	}
+	if defaults.GoVersion != "" && unset("go") {
+		goVersion = defaults.GoVersion
+	}
+	if len(defaults.Features) > 0 && unset("features") {
+		features = defaults.Features
+	}
+	if defaults.Output != "" && unset("output") {
+		outputDir = expandHome(defaults.Output)
+	}
+	if defaults.Git != nil && unset("git") {
+		gitInit = *defaults.Git
+	}
+	return nil
+}
+
+// resolveTemplateDir returns the template directory named by --template. A
+// name that is not an existing path is looked up in the template directories
+// of the user defaults.
+func resolveTemplateDir(name string) string {
+	if name == "" || strings.ContainsRune(name, filepath.Separator) {
+		return name
+	}
+	if _, err := os.Stat(name); err == nil {
+		return name
+	}
+
+	for _, dir := range defaults.Templates {
+		candidate := filepath.Join(expandHome(dir), name)
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate
+		}
+	}
+	return name
+}
+
+// expandHome replaces a leading ~ in path with the user's home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
+// splitList splits a comma separated list, dropping empty elements.
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
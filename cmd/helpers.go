@@ -5,9 +5,17 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 )
 
 func initGitRepo(projectPath string) {
+	if _, err := os.Stat(filepath.Join(projectPath, ".git")); err == nil {
+		if verbose {
+			fmt.Println("Git repository already exists, skipping initialization")
+		}
+		return
+	}
+
 	if verbose {
 		fmt.Println("Initializing git repository...")
 	}
@@ -94,3 +102,15 @@ func printNextSteps(projectName string) {
 	fmt.Println("   go run main.go")
 	fmt.Println("\n📚 For more information, check the README.md file in your project")
 }
+
+// gitUserName returns the user name from the git configuration seen from
+// dir, or an empty string.
+func gitUserName(dir string) string {
+	cmd := exec.Command("git", "config", "user.name")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/Hassani-Jr/go-project-generator/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create a project with an interactive wizard",
+	Long: `Create a project with an interactive wizard that asks for the project type,
+name, module path, optional features, license and author.
+
+Answers are read line by line from standard input, an empty line accepts the
+default shown in brackets. The wizard can therefore be scripted:
+
+  printf 'web\nuser-api\ngithub.com/ourorg/user-api\ndocker,ci\nMIT\nJane Doe\n' | go-project-generator init`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runWizard(cmd)
+	},
+}
+
+// runWizard asks for the project configuration on the command's input and
+// generates the project.
+func runWizard(cmd *cobra.Command) {
+	w := &wizard{in: bufio.NewReader(cmd.InOrStdin()), out: cmd.OutOrStdout()}
+
+	projectType, config, err := w.run()
+	if err != nil {
+		log.Fatalf("Failed to create project: %v", err)
+	}
+
+	runGenerator(config, projectType.Title())
+}
+
+type wizard struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// run asks every question and returns the selected project type and the
+// resulting configuration. Command line flags and the project file provide
+// the defaults.
+func (w *wizard) run() (generator.ProjectType, generator.ProjectConfig, error) {
+	var (
+		projectType generator.ProjectType
+		config      generator.ProjectConfig
+	)
+
+	file, err := loadProjectFile()
+	if err != nil {
+		return nil, config, err
+	}
+	defaultType := file.Type
+	if defaultType == "" {
+		defaultType = "cli"
+	}
+
+	_, _ = fmt.Fprintln(w.out, "Project types:")
+	var typeNames []string
+	for _, t := range generator.Types() {
+		_, _ = fmt.Fprintf(w.out, "  %-14s %s\n", t.Name(), t.Description())
+		typeNames = append(typeNames, t.Name())
+	}
+
+	err = w.ask(fmt.Sprintf("Project type (%s)", strings.Join(typeNames, ", ")), defaultType, func(answer string) error {
+		var err error
+		projectType, err = resolveProjectType(answer)
+		return err
+	})
+	if err != nil {
+		return nil, config, err
+	}
+
+	var projectName string
+	err = w.ask("Project name", file.Name, func(answer string) error {
+		_, err := generator.ParseName(answer)
+		projectName = answer
+		return err
+	})
+	if err != nil {
+		return nil, config, err
+	}
+
+	config = newProjectConfig(projectType, projectName, file)
+	if config.OnConflict == generator.ConflictAsk {
+		// Conflict prompts share the wizard's buffered input.
+		config.ResolveConflict = newConflictPrompt(w.in, w.out)
+	}
+
+	err = w.ask("Module path", config.ModulePath, func(answer string) error {
+		config.ModulePath = answer
+		return generator.ValidateModulePath(answer)
+	})
+	if err != nil {
+		return nil, config, err
+	}
+
+	defaultFeatures := strings.Join(config.Features, ",")
+	if defaultFeatures == "" {
+		defaultFeatures = "none"
+	}
+	err = w.ask(fmt.Sprintf("Features (comma separated: %s, or none)", featureNames(projectType.Features())), defaultFeatures, func(answer string) error {
+		config.Features = nil
+		if answer == "none" {
+			return nil
+		}
+		for _, f := range strings.Split(answer, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				config.Features = append(config.Features, f)
+			}
+		}
+		if err := generator.ValidateFeatures(config.Features); err != nil || config.TemplateDir != "" {
+			return err
+		}
+		return generator.ValidateTypeFeatures(projectType, config.Features)
+	})
+	if err != nil {
+		return nil, config, err
+	}
+
+	defaultLicense := config.License
+	if defaultLicense == "" {
+		defaultLicense = "MIT"
+	}
+	err = w.ask(fmt.Sprintf("License (%s, %s)", strings.Join(generator.Licenses(), ", "), generator.LicenseNone), defaultLicense, func(answer string) error {
+		var err error
+		config.License, err = generator.ValidateLicense(answer)
+		return err
+	})
+	if err != nil {
+		return nil, config, err
+	}
+
+	defaultAuthor := config.Author
+	if defaultAuthor == "" {
+		defaultAuthor = gitUserName(filepath.Dir(config.ProjectPath))
+	}
+	err = w.ask("Author", defaultAuthor, func(answer string) error {
+		config.Author = answer
+		return nil
+	})
+	if err != nil {
+		return nil, config, err
+	}
+
+	_, _ = fmt.Fprintln(w.out)
+	return projectType, config, nil
+}
+
+// ask prints question and passes the answer, or def for an empty answer, to
+// accept. Rejected answers are reported and the question is asked again
+// until the input ends.
+func (w *wizard) ask(question, def string, accept func(answer string) error) error {
+	for {
+		if def != "" {
+			_, _ = fmt.Fprintf(w.out, "%s [%s]: ", question, def)
+		} else {
+			_, _ = fmt.Fprintf(w.out, "%s: ", question)
+		}
+
+		line, err := w.in.ReadString('\n')
+		eof := err == io.EOF
+		if err != nil && !eof {
+			return err
+		}
+		if eof {
+			_, _ = fmt.Fprintln(w.out)
+		}
+
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			answer = def
+		}
+
+		err = accept(answer)
+		if err == nil {
+			return nil
+		}
+		if eof {
+			return fmt.Errorf("%s: %w", strings.ToLower(question), err)
+		}
+		_, _ = fmt.Fprintf(w.out, "  %v\n", err)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInitCommandGeneratesProject(t *testing.T) {
+	tempDir := t.TempDir()
+
+	answers := strings.Join([]string{
+		"webservice",
+		"not a name!",
+		"user-api",
+		"github.com/ourorg/user-api",
+		"docker, ci",
+		"isc",
+		"Jane Doe",
+	}, "\n") + "\n"
+
+	rootCmd.SetIn(strings.NewReader(answers))
+	defer rootCmd.SetIn(nil)
+	rootCmd.SetArgs([]string{"init", "--output", tempDir, "--git=false"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	projectPath := filepath.Join(tempDir, "user-api")
+	for _, file := range []string{"main.go", "Dockerfile", ".github/workflows/ci.yml"} {
+		if _, err := os.Stat(filepath.Join(projectPath, file)); err != nil {
+			t.Errorf("Expected file %s was not created: %v", file, err)
+		}
+	}
+
+	goMod, err := os.ReadFile(filepath.Join(projectPath, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(goMod), "module github.com/ourorg/user-api\n") {
+		t.Errorf("go.mod = %q, want module github.com/ourorg/user-api", goMod)
+	}
+
+	license, err := os.ReadFile(filepath.Join(projectPath, "LICENSE"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(license), "ISC License") || !strings.Contains(string(license), "Jane Doe") {
+		t.Errorf("LICENSE = %q, want ISC license for Jane Doe", license)
+	}
+}
+
+func TestWizardDefaults(t *testing.T) {
+	w := &wizard{in: bufio.NewReader(strings.NewReader("\nmy-app\n")), out: &strings.Builder{}}
+
+	projectType, config, err := w.run()
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if projectType.Name() != "cli" {
+		t.Errorf("project type = %s, want cli", projectType.Name())
+	}
+	if config.ProjectName != "my-app" {
+		t.Errorf("ProjectName = %s, want my-app", config.ProjectName)
+	}
+	if config.License != "MIT" {
+		t.Errorf("License = %s, want MIT", config.License)
+	}
+	if len(config.Features) != 0 {
+		t.Errorf("Features = %v, want none", config.Features)
+	}
+}
+
+func TestWizardUnsupportedFeature(t *testing.T) {
+	out := &strings.Builder{}
+	answers := "library\nmy-lib\nexample.com/my-lib\ndocker\nci\n\n\n"
+	w := &wizard{in: bufio.NewReader(strings.NewReader(answers)), out: out}
+
+	_, config, err := w.run()
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "Features (comma separated: ci, or none)") {
+		t.Errorf("wizard offered other features than ci:\n%s", out)
+	}
+	if !strings.Contains(out.String(), `do not support the "docker" feature`) {
+		t.Errorf("wizard accepted docker for a library:\n%s", out)
+	}
+	if strings.Join(config.Features, ",") != "ci" {
+		t.Errorf("Features = %v, want ci", config.Features)
+	}
+}
+
+func TestWizardMissingName(t *testing.T) {
+	w := &wizard{in: bufio.NewReader(strings.NewReader("cli\n")), out: &strings.Builder{}}
+
+	if _, _, err := w.run(); err == nil {
+		t.Error("run() succeeded without a project name")
+	}
+}
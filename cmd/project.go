@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Hassani-Jr/go-project-generator/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// newProjectCmd returns the subcommand that generates projectType.
+func newProjectCmd(projectType generator.ProjectType) *cobra.Command {
+	short := fmt.Sprintf("Generate a %s project", projectType.Title())
+
+	cmd := &cobra.Command{
+		Use:     projectType.Name() + " [project-name]",
+		Aliases: projectType.Aliases(),
+		Short:   short,
+		Long:    short + ".\n\n" + projectType.Description(),
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var projectName string
+			if len(args) > 0 {
+				projectName = args[0]
+			}
+			return generateProject(projectType, projectName, optionFlags(cmd, projectType))
+		},
+	}
+
+	for _, option := range projectType.Options() {
+		usage := option.Description
+		switch {
+		case option.Default != "" && option.Feature != "":
+			usage += fmt.Sprintf(" (%s with the %s feature)", option.Default, option.Feature)
+		case option.Default != "":
+			usage += fmt.Sprintf(" (default %s)", option.Default)
+		}
+		if option.List {
+			cmd.Flags().StringSlice(option.Name, nil, usage)
+		} else {
+			cmd.Flags().String(option.Name, "", usage)
+		}
+	}
+	return cmd
+}
+
+// optionFlags returns the values of the project type's option flags that
+// were set on the command line.
+func optionFlags(cmd *cobra.Command, projectType generator.ProjectType) map[string]string {
+	options := make(map[string]string)
+	for _, option := range projectType.Options() {
+		flag := cmd.Flags().Lookup(option.Name)
+		if flag == nil || !flag.Changed {
+			continue
+		}
+		if option.List {
+			values, _ := cmd.Flags().GetStringSlice(option.Name)
+			options[option.Name] = strings.Join(values, ",")
+		} else {
+			options[option.Name] = flag.Value.String()
+		}
+	}
+	return options
+}
+
+// generateProject generates a projectType project named projectName in the
+// output directory and reports the result. A nil projectType or an empty
+// projectName is taken from the project file given with --config. options
+// take precedence over the options in the project file.
+func generateProject(projectType generator.ProjectType, projectName string, options map[string]string) error {
+	file, err := loadProjectFile()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case projectType == nil && file.Type == "":
+		return errors.New("no project type given: pass it as an argument or set type in the project file")
+	case projectType == nil:
+		if projectType, err = resolveProjectType(file.Type); err != nil {
+			return err
+		}
+	case file.Type != "":
+		if fileType, ok := generator.Lookup(file.Type); !ok || fileType.Name() != projectType.Name() {
+			return fmt.Errorf("project file %s describes a %s project, not a %s project", configFile, file.Type, projectType.Name())
+		}
+	}
+
+	if projectName == "" {
+		projectName = file.Name
+	}
+	if projectName == "" {
+		return errors.New("no project name given: pass it as an argument or set name in the project file")
+	}
+
+	// Documents named in the project file are relative to the file.
+	for _, option := range projectType.Options() {
+		if value := file.Options[option.Name]; option.Type == generator.OptionOpenAPI && value != "" && !filepath.IsAbs(value) {
+			file.Options[option.Name] = filepath.Join(filepath.Dir(configFile), value)
+		}
+	}
+
+	if len(options) > 0 {
+		merged := make(map[string]string, len(file.Options)+len(options))
+		for name, value := range file.Options {
+			merged[name] = value
+		}
+		for name, value := range options {
+			merged[name] = value
+		}
+		file.Options = merged
+	}
+
+	runGenerator(newProjectConfig(projectType, projectName, file), projectType.Title())
+	return nil
+}
+
+// loadProjectFile reads the project file given with --config. Without one
+// it returns an empty project file.
+func loadProjectFile() (*generator.ProjectFile, error) {
+	if configFile == "" {
+		return &generator.ProjectFile{}, nil
+	}
+	return generator.LoadProjectFile(configFile)
+}
+
+// newProjectConfig returns the configuration for a projectType project named
+// projectName. Flags given on the command line take precedence over the
+// project file, which takes precedence over the flag defaults.
+func newProjectConfig(projectType generator.ProjectType, projectName string, file *generator.ProjectFile) generator.ProjectConfig {
+	projectPath := filepath.Join(outputDir, projectName)
+
+	module := stringOption("module", modulePath, file.Module)
+	if module == "" && defaults.ModulePrefix != "" {
+		if names, err := generator.ParseName(projectName); err == nil && names.ModuleSegment != "" {
+			module = defaults.ModulePrefix + "/" + names.ModuleSegment
+		}
+	}
+	if module == "" {
+		module = generator.DefaultModulePath(projectPath, projectName)
+	}
+
+	projectFeatures := features
+	switch {
+	case flagChanged("features"):
+	case len(file.Features) > 0:
+		projectFeatures = file.Features
+	case templateDir == "":
+		// The user defaults apply to every project type, so they only
+		// select the features this one supports.
+		projectFeatures = supportedFeatures(projectType, features)
+	}
+
+	config := generator.ProjectConfig{
+		ProjectName:  projectName,
+		ProjectPath:  projectPath,
+		ProjectType:  projectType.Name(),
+		ModulePath:   module,
+		GitInit:      gitInit,
+		Features:     projectFeatures,
+		License:      stringOption("license", license, file.License),
+		Author:       stringOption("author", author, file.Author),
+		GoVersion:    stringOption("go", goVersion, file.GoVersion),
+		Options:      file.Options,
+		Dependencies: file.Dependencies,
+		TemplateDir:  resolveTemplateDir(templateDir),
+		GoProxy:      goProxy,
+		Verify:       verify,
+		Rollback:     rollback,
+		DryRun:       dryRun,
+		OnConflict:   conflictMode(),
+	}
+	if config.OnConflict == generator.ConflictAsk {
+		config.ResolveConflict = newConflictPrompt(os.Stdin, os.Stdout)
+	}
+	return config
+}
+
+// supportedFeatures returns the names of the features t supports.
+func supportedFeatures(t generator.ProjectType, names []string) []string {
+	var supported []string
+	for _, name := range names {
+		if generator.ValidateTypeFeatures(t, []string{name}) == nil {
+			supported = append(supported, name)
+		}
+	}
+	return supported
+}
+
+// flagChanged reports whether the persistent flag name was set on the
+// command line.
+func flagChanged(name string) bool {
+	return rootCmd.PersistentFlags().Changed(name)
+}
+
+// stringOption returns fileValue if it is set and the flag name was not given
+// on the command line, and flagValue otherwise.
+func stringOption(name, flagValue, fileValue string) string {
+	if fileValue != "" && !flagChanged(name) {
+		return fileValue
+	}
+	return flagValue
+}
+
+// runGenerator generates the project described by config and reports the
+// result. title is the display name of the project type.
+func runGenerator(config generator.ProjectConfig, title string) {
+	if verbose {
+		fmt.Printf("Creating %s project: %s\n", title, config.ProjectName)
+		fmt.Printf("Output directory: %s\n", config.ProjectPath)
+		fmt.Printf("Module path: %s\n", config.ModulePath)
+	}
+
+	gen := generator.New(config)
+	if err := gen.Generate(); err != nil {
+		if errors.Is(err, generator.ErrProjectExists) {
+			log.Fatalf("Failed to generate %s project: %v (use --force, --skip-existing or --merge)", title, err)
+		}
+		log.Fatalf("Failed to generate %s project: %v", title, err)
+	}
+
+	if config.DryRun {
+		if err := generator.WritePlan(os.Stdout, config.ProjectPath, gen.Operations(), showContent); err != nil {
+			log.Fatalf("Failed to print the project plan: %v", err)
+		}
+		return
+	}
+
+	fmt.Printf("✅ %s project '%s' created successfully!\n", title, config.ProjectName)
+	for _, warning := range gen.Warnings() {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+	if config.Verify {
+		fmt.Println("✅ Project verified: go mod tidy, vet, build and test passed")
+	}
+
+	if config.GitInit {
+		initGitRepo(config.ProjectPath)
+	}
+
+	printNextSteps(config.ProjectName)
+}
+
+func init() {
+	for _, projectType := range generator.Types() {
+		rootCmd.AddCommand(newProjectCmd(projectType))
+	}
+}
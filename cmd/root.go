@@ -2,14 +2,33 @@ package cmd
 
 import (
 	"fmt"
-	"github.com/spf13/cobra"
 	"os"
+	"strings"
+
+	"github.com/Hassani-Jr/go-project-generator/internal/generator"
+	"github.com/spf13/cobra"
 )
 
 var (
-	outputDir string
-	gitInit   bool
-	verbose   bool
+	outputDir    string
+	gitInit      bool
+	verbose      bool
+	templateDir  string
+	modulePath   string
+	goProxy      string
+	verify       bool
+	rollback     bool
+	dryRun       bool
+	showContent  bool
+	force        bool
+	skipExisting bool
+	merge        bool
+	features     []string
+	license      string
+	author       string
+	interactive  bool
+	configFile   string
+	goVersion    string
 )
 
 var rootCmd = &cobra.Command{
@@ -18,12 +37,20 @@ var rootCmd = &cobra.Command{
 	Long: `Go Project Generator is a powerful CLI tool designed to streamline 
 the process of creating new Go projects. It provides quick, consistent 
 project scaffolding for various project types.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		err := cmd.Help()
-		if err != nil {
-			return
-		}
-	},
+}
+
+// runRoot runs the wizard with --interactive, generates the project file
+// given with --config and prints the help otherwise.
+func runRoot(cmd *cobra.Command, args []string) error {
+	if interactive {
+		runWizard(cmd)
+		return nil
+	}
+	if configFile != "" {
+		return generateProject(nil, "", nil)
+	}
+
+	return cmd.Help()
 }
 
 func Execute() {
@@ -34,7 +61,40 @@ func Execute() {
 }
 
 func init() {
+	// Project types are matched case-insensitively, e.g. "CLI" or "Web".
+	cobra.EnableCaseInsensitive = true
+
+	// Set here rather than in the literal: runRoot refers back to rootCmd.
+	rootCmd.RunE = runRoot
+	rootCmd.PersistentPreRunE = applyDefaults
+
 	rootCmd.PersistentFlags().StringVarP(&outputDir, "output", "o", ".", "Output directory for the project")
 	rootCmd.PersistentFlags().BoolVarP(&gitInit, "git", "g", true, "Initialize git repository")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
+	rootCmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Create a project with an interactive wizard, like init")
+	rootCmd.PersistentFlags().StringVar(&modulePath, "module", "", "Go module path of the project (default: derived from GOPATH or the git remote)")
+	rootCmd.PersistentFlags().StringVar(&goProxy, "goproxy", "", "Module proxy URLs to look up the latest dependency versions (default: built-in catalog)")
+	rootCmd.PersistentFlags().BoolVar(&verify, "verify", false, "Run go mod tidy, vet, build and test in the generated project")
+	rootCmd.PersistentFlags().BoolVar(&rollback, "rollback", false, "Remove the generated project if --verify fails")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the files that would be generated without writing them")
+	rootCmd.PersistentFlags().BoolVar(&showContent, "show-contents", false, "With --dry-run, also print the content of every file")
+	rootCmd.PersistentFlags().BoolVarP(&force, "force", "f", false, "Overwrite files in an existing project directory")
+	rootCmd.PersistentFlags().BoolVar(&skipExisting, "skip-existing", false, "Keep files that already exist in the project directory")
+	rootCmd.PersistentFlags().BoolVar(&merge, "merge", false, "Ask, showing a diff, whether to overwrite each existing file")
+	rootCmd.MarkFlagsMutuallyExclusive("force", "skip-existing", "merge")
+	rootCmd.PersistentFlags().StringSliceVar(&features, "features", nil, "Optional features to generate: "+featureNames(generator.Features()))
+	rootCmd.PersistentFlags().StringVar(&license, "license", "", "License of the project: "+strings.Join(generator.Licenses(), ", ")+" or "+generator.LicenseNone)
+	rootCmd.PersistentFlags().StringVar(&author, "author", "", "Author named in the license")
+	rootCmd.PersistentFlags().StringVar(&goVersion, "go", "", "Go version declared in go.mod (default: "+generator.DefaultGoVersion+")")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "YAML or JSON project file describing the project to generate")
+	rootCmd.PersistentFlags().StringVarP(&templateDir, "template", "t", "", "Template directory to render instead of the built-in scaffold")
+}
+
+// featureNames returns the names of features, comma separated.
+func featureNames(features []generator.Feature) string {
+	var names []string
+	for _, f := range features {
+		names = append(names, f.Name)
+	}
+	return strings.Join(names, ", ")
 }
@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -17,7 +21,7 @@ func TestRootCommand(t *testing.T) {
 }
 
 func TestSubCommands(t *testing.T) {
-	subCommands := []string{"cli", "web", "microservice", "library", "tool"}
+	subCommands := []string{"cli", "web", "microservice", "library", "tool", "create", "CLI", "webservice", "lib"}
 
 	for _, cmdName := range subCommands {
 		cmd, _, err := rootCmd.Find([]string{cmdName})
@@ -31,3 +35,240 @@ func TestSubCommands(t *testing.T) {
 		}
 	}
 }
+
+func TestToolCommandGeneratesProject(t *testing.T) {
+	tempDir := t.TempDir()
+
+	rootCmd.SetArgs([]string{"tool", "my-tool", "--output", tempDir, "--git=false"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	files := []string{
+		"main.go",
+		"cmd/commands/process.go",
+		"cmd/commands/analyze.go",
+		"internal/utils/utils.go",
+		"go.mod",
+		"README.md",
+	}
+	for _, file := range files {
+		path := filepath.Join(tempDir, "my-tool", file)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			t.Errorf("Expected file %s was not created", file)
+		}
+	}
+}
+
+func TestResolveProjectType(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "cli", want: "cli"},
+		{name: "webservice", want: "web"},
+		{name: "lib", want: "library"},
+		{name: "micro", want: "microservice"},
+		{name: "TOOL", want: "tool"},
+		{name: "unknown", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveProjectType(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveProjectType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Name() != tt.want {
+				t.Errorf("resolveProjectType() = %v, want %v", got.Name(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateCommandGeneratesProject(t *testing.T) {
+	tempDir := t.TempDir()
+
+	rootCmd.SetArgs([]string{"create", "webservice", "user-api", "--output", tempDir, "--git=false"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	path := filepath.Join(tempDir, "user-api", "internal/handlers/handlers.go")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		t.Errorf("Expected file %s was not created", path)
+	}
+}
+
+func TestConfigFlagGeneratesProject(t *testing.T) {
+	tempDir := t.TempDir()
+	resetFlags(t)
+
+	projectFile := filepath.Join(tempDir, "project.yaml")
+	content := `name: user-api
+type: web
+module: github.com/ourorg/user-api
+features: [docker]
+license: MIT
+author: Our Org
+go: "1.23"
+`
+	if err := os.WriteFile(projectFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rootCmd.SetArgs([]string{"--config", projectFile, "--author", "Jane Doe", "--output", tempDir, "--git=false"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	projectPath := filepath.Join(tempDir, "user-api")
+	if _, err := os.Stat(filepath.Join(projectPath, "Dockerfile")); err != nil {
+		t.Errorf("Expected Dockerfile was not created: %v", err)
+	}
+
+	goMod, err := os.ReadFile(filepath.Join(projectPath, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(goMod), "module github.com/ourorg/user-api\n\ngo 1.23\n") {
+		t.Errorf("go.mod = %q, want module github.com/ourorg/user-api and go 1.23", goMod)
+	}
+
+	// The --author flag takes precedence over the project file.
+	license, err := os.ReadFile(filepath.Join(projectPath, "LICENSE"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(license), "Jane Doe") {
+		t.Errorf("LICENSE = %q, want copyright holder Jane Doe", license)
+	}
+
+	rootCmd.SetArgs([]string{"cli", "--config", projectFile, "--output", tempDir, "--git=false"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Error("Execute() with a project file for another project type error = nil, want error")
+	}
+}
+
+func TestOptionFlags(t *testing.T) {
+	tempDir := t.TempDir()
+	resetFlags(t)
+
+	webCmd, _, err := rootCmd.Find([]string{"web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbFlag := webCmd.Flags().Lookup("db")
+	if dbFlag == nil {
+		t.Fatal("web command has no --db flag")
+	}
+	t.Cleanup(func() {
+		_ = dbFlag.Value.Set(dbFlag.DefValue)
+		dbFlag.Changed = false
+	})
+
+	projectFile := filepath.Join(tempDir, "project.yaml")
+	if err := os.WriteFile(projectFile, []byte("options:\n  db: mysql\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The --db flag takes precedence over the project file.
+	rootCmd.SetArgs([]string{"web", "my-service", "--db", "sqlite", "--config", projectFile, "--output", tempDir, "--git=false"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	config, err := os.ReadFile(filepath.Join(tempDir, "my-service", "configs", "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(config), "driver: sqlite") {
+		t.Errorf("configs/config.yaml = %q, want the sqlite driver", config)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "my-service", "pkg", "database", "database.go")); err != nil {
+		t.Errorf("Expected pkg/database/database.go was not created: %v", err)
+	}
+}
+
+func TestCreateOptions(t *testing.T) {
+	tempDir := t.TempDir()
+	resetFlags(t)
+	t.Cleanup(func() {
+		createOptions = nil
+		createCmd.Flags().Lookup("option").Changed = false
+	})
+
+	if _, err := executeRoot(t, "create", "web", "my-service", "--option", "db=sqlite", "--option", "router=chi", "--output", tempDir, "--git=false"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	projectPath := filepath.Join(tempDir, "my-service")
+	if _, err := os.Stat(filepath.Join(projectPath, "pkg", "database", "database.go")); err != nil {
+		t.Errorf("Expected pkg/database/database.go was not created: %v", err)
+	}
+	goMod, err := os.ReadFile(filepath.Join(projectPath, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(goMod), "github.com/go-chi/chi/v5") {
+		t.Errorf("go.mod = %q, want the chi router", goMod)
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	options, err := parseOptions([]string{"db=sqlite", "middleware=logging,cors", "auth="})
+	if err != nil {
+		t.Fatalf("parseOptions() error = %v", err)
+	}
+	want := map[string]string{"db": "sqlite", "middleware": "logging,cors", "auth": ""}
+	if !reflect.DeepEqual(options, want) {
+		t.Errorf("parseOptions() = %v, want %v", options, want)
+	}
+
+	for _, value := range []string{"sqlite", "=sqlite"} {
+		if _, err := parseOptions([]string{value}); err == nil {
+			t.Errorf("parseOptions(%q) error = nil, want error", value)
+		}
+	}
+}
+
+func TestOpenAPIProjectFile(t *testing.T) {
+	tempDir := t.TempDir()
+	resetFlags(t)
+
+	// The document is named relative to the project file, not to the
+	// working directory.
+	specDir := filepath.Join(tempDir, "spec")
+	if err := os.Mkdir(specDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	doc := "openapi: 3.0.3\ninfo:\n  title: Pings\n  version: v1\npaths:\n  /ping:\n    get:\n      operationId: ping\n      responses:\n        \"204\":\n          description: Pong\n"
+	if err := os.WriteFile(filepath.Join(specDir, "api.yaml"), []byte(doc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	projectFile := filepath.Join(specDir, "project.yaml")
+	if err := os.WriteFile(projectFile, []byte("type: web\nname: pinger\noptions:\n  openapi: api.yaml\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rootCmd.SetArgs([]string{"create", "--config", projectFile, "--output", tempDir, "--git=false"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(tempDir, "pinger", "api", "openapi.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != doc {
+		t.Errorf("api/openapi.yaml = %q, want %q", got, doc)
+	}
+	routes, err := os.ReadFile(filepath.Join(tempDir, "pinger", "internal", "handlers", "routes.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(routes), `mux.HandleFunc("GET /ping", operations.servePing)`) {
+		t.Errorf("routes.go does not serve the ping operation:\n%s", routes)
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Hassani-Jr/go-project-generator/internal/server"
+	"github.com/spf13/cobra"
+)
+
+var serveAddr string
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve an HTTP API for generating projects",
+	Long: `Serve an HTTP API for generating projects:
+
+  GET  /types                  list the project types
+  GET  /types/{type}/schema    JSON Schema of the generate request
+  POST /types/{type}/generate  generate a project, returned as a zip or, with
+                               ?format=tar.gz, as a tar.gz archive
+
+The generate request body is a JSON project file, for example:
+
+  curl -o user-api.zip -d '{"name": "user-api", "features": ["docker"]}' \
+    http://localhost:8080/types/web/generate`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		srv := &http.Server{
+			Addr:              serveAddr,
+			Handler:           server.NewHandler(server.Options{GoProxy: goProxy}),
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      2 * time.Minute,
+			IdleTimeout:       2 * time.Minute,
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		errc := make(chan error, 1)
+		go func() {
+			errc <- srv.ListenAndServe()
+		}()
+		fmt.Printf("Listening on %s\n", serveAddr)
+
+		select {
+		case err := <-errc:
+			return err
+		case <-ctx.Done():
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+		if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "Address to listen on")
+	rootCmd.AddCommand(serveCmd)
+}
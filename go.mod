@@ -2,9 +2,10 @@ module github.com/Hassani-Jr/go-project-generator
 
 go 1.24.1
 
-require github.com/spf13/cobra v1.9.1
-
 require (
-	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/spf13/pflag v1.0.6 // indirect
+	github.com/spf13/cobra v1.9.1
+	github.com/spf13/pflag v1.0.6
+	gopkg.in/yaml.v3 v3.0.1
 )
+
+require github.com/inconshreveable/mousetrap v1.1.0 // indirect
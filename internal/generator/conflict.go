@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// ConflictMode decides what happens to files that already exist in the
+// project directory.
+type ConflictMode string
+
+const (
+	// ConflictFail refuses to generate into a non-empty project directory.
+	ConflictFail ConflictMode = ""
+	// ConflictOverwrite replaces existing files.
+	ConflictOverwrite ConflictMode = "overwrite"
+	// ConflictSkip keeps existing files and only writes new ones.
+	ConflictSkip ConflictMode = "skip"
+	// ConflictAsk lets ProjectConfig.ResolveConflict decide for every
+	// existing file whose content differs from the generated one.
+	ConflictAsk ConflictMode = "ask"
+)
+
+// Conflict is a generated file that already exists with different content.
+type Conflict struct {
+	// Path is the slash separated path relative to the project.
+	Path      string
+	Existing  string
+	Generated string
+}
+
+// Diff returns a unified diff from the existing to the generated content.
+func (c Conflict) Diff() string {
+	return UnifiedDiff("existing/"+c.Path, "generated/"+c.Path, c.Existing, c.Generated)
+}
+
+// ConflictResolver reports whether the existing file of a conflict should be
+// overwritten with the generated content.
+type ConflictResolver func(c Conflict) (overwrite bool, err error)
+
+// ErrProjectExists is returned when generating into a non-empty directory
+// without a conflict mode that allows it.
+var ErrProjectExists = errors.New("project directory already exists and is not empty")
+
+// checkProjectDir fails if the project directory is not empty and existing
+// files may not be touched. Projects written to Config.Output always start
+// empty.
+func (g *Generator) checkProjectDir() error {
+	if g.Config.Output != nil {
+		return nil
+	}
+	info, err := os.Stat(g.Config.ProjectPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s exists and is not a directory", g.Config.ProjectPath)
+	}
+
+	switch g.Config.OnConflict {
+	case ConflictFail:
+		entries, err := os.ReadDir(g.Config.ProjectPath)
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			return fmt.Errorf("%s: %w", g.Config.ProjectPath, ErrProjectExists)
+		}
+	case ConflictOverwrite, ConflictSkip:
+	case ConflictAsk:
+		if g.Config.ResolveConflict == nil {
+			return fmt.Errorf("conflict mode %q needs a conflict resolver", ConflictAsk)
+		}
+	default:
+		return fmt.Errorf("unknown conflict mode %q", g.Config.OnConflict)
+	}
+	return nil
+}
+
+// keepExisting reports whether the existing file at path, if any, should be
+// kept instead of being replaced with content.
+func (g *Generator) keepExisting(path, fullPath, content string) (bool, error) {
+	if g.Config.Output != nil || g.Config.OnConflict == ConflictOverwrite || g.Config.OnConflict == ConflictFail {
+		return false, nil
+	}
+
+	existing, err := os.ReadFile(fullPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if g.Config.OnConflict == ConflictSkip || string(existing) == content {
+		return true, nil
+	}
+
+	overwrite, err := g.Config.ResolveConflict(Conflict{Path: path, Existing: string(existing), Generated: content})
+	if err != nil {
+		return false, err
+	}
+	return !overwrite, nil
+}
@@ -0,0 +1,148 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func generateTool(t *testing.T, projectPath string, mode ConflictMode, resolve ConflictResolver) error {
+	t.Helper()
+
+	gen := New(ProjectConfig{
+		ProjectName:     "mytool",
+		ProjectPath:     projectPath,
+		ProjectType:     "tool",
+		OnConflict:      mode,
+		ResolveConflict: resolve,
+	})
+	return gen.Generate()
+}
+
+func TestGenerator_GenerateConflicts(t *testing.T) {
+	const edited = "package main // edited\n"
+
+	setup := func(t *testing.T) string {
+		projectPath := filepath.Join(t.TempDir(), "mytool")
+		if err := generateTool(t, projectPath, ConflictFail, nil); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(projectPath, "main.go"), []byte(edited), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return projectPath
+	}
+
+	readMain := func(t *testing.T, projectPath string) string {
+		content, err := os.ReadFile(filepath.Join(projectPath, "main.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		projectPath := setup(t)
+		if err := generateTool(t, projectPath, ConflictFail, nil); !errors.Is(err, ErrProjectExists) {
+			t.Errorf("Generate() error = %v, want ErrProjectExists", err)
+		}
+		if readMain(t, projectPath) != edited {
+			t.Errorf("main.go was overwritten")
+		}
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		projectPath := setup(t)
+		if err := generateTool(t, projectPath, ConflictOverwrite, nil); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if readMain(t, projectPath) == edited {
+			t.Errorf("main.go was not overwritten")
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		projectPath := setup(t)
+		if err := os.Remove(filepath.Join(projectPath, "README.md")); err != nil {
+			t.Fatal(err)
+		}
+		if err := generateTool(t, projectPath, ConflictSkip, nil); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if readMain(t, projectPath) != edited {
+			t.Errorf("main.go was overwritten")
+		}
+		if _, err := os.Stat(filepath.Join(projectPath, "README.md")); err != nil {
+			t.Errorf("Missing README.md was not generated: %v", err)
+		}
+	})
+
+	t.Run("ask", func(t *testing.T) {
+		for _, overwrite := range []bool{false, true} {
+			projectPath := setup(t)
+
+			var asked []Conflict
+			err := generateTool(t, projectPath, ConflictAsk, func(c Conflict) (bool, error) {
+				asked = append(asked, c)
+				return overwrite, nil
+			})
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			// Unchanged files are not conflicts.
+			if len(asked) != 1 || asked[0].Path != "main.go" || asked[0].Existing != edited {
+				t.Fatalf("conflicts = %+v, want main.go only", asked)
+			}
+			if got := readMain(t, projectPath) == edited; got == overwrite {
+				t.Errorf("overwrite = %v, but main.go kept = %v", overwrite, got)
+			}
+		}
+	})
+
+	t.Run("ask error", func(t *testing.T) {
+		projectPath := setup(t)
+		errQuit := errors.New("quit")
+		err := generateTool(t, projectPath, ConflictAsk, func(Conflict) (bool, error) {
+			return false, errQuit
+		})
+		if !errors.Is(err, errQuit) {
+			t.Errorf("Generate() error = %v, want %v", err, errQuit)
+		}
+	})
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	a := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"
+	b := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nm\nn\n"
+
+	want := `--- old
++++ new
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -9,5 +9,5 @@
+ i
+ j
+ k
+-l
+ m
++n
+`
+	if got := UnifiedDiff("old", "new", a, b); got != want {
+		t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := UnifiedDiff("old", "new", a, a); got != "" {
+		t.Errorf("UnifiedDiff() of equal content = %q, want empty", got)
+	}
+
+	if got := UnifiedDiff("old", "new", "", "x\n"); got != "--- old\n+++ new\n@@ -0,0 +1 @@\n+x\n" {
+		t.Errorf("UnifiedDiff() of new content = %q", got)
+	}
+}
@@ -0,0 +1,252 @@
+package generator
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dependencyCatalog pins the version of every module the built-in templates
+// import. Keep it in sync with the templates.
+//
+//go:embed dependencies.json
+var dependencyCatalog []byte
+
+// Requirement is a module listed in the require block of a generated go.mod.
+type Requirement struct {
+	Path    string
+	Version string
+}
+
+// Catalog maps module paths to the version generated projects require.
+type Catalog map[string]string
+
+// DefaultCatalog returns the dependency catalog shipped with the generator.
+func DefaultCatalog() Catalog {
+	var catalog Catalog
+	if err := json.Unmarshal(dependencyCatalog, &catalog); err != nil {
+		panic(fmt.Sprintf("generator: invalid dependencies.json: %v", err))
+	}
+	return catalog
+}
+
+// Resolver determines the modules, and their versions, that provide a set of
+// imported packages.
+type Resolver struct {
+	// Catalog holds the known module versions.
+	Catalog Catalog
+	// Proxy is an optional GOPROXY style, comma separated list of module
+	// proxy URLs. When set, the latest version of each module is looked up
+	// there first, falling back to the catalog. file:// URLs are supported.
+	Proxy string
+	// Client performs proxy requests. It defaults to a client with a short
+	// timeout that also understands file:// URLs.
+	Client *http.Client
+}
+
+// Resolve returns the requirements, sorted by module path, for the given
+// import paths. Standard library packages and packages inside modulePath are
+// ignored.
+func (r *Resolver) Resolve(modulePath string, imports []string) ([]Requirement, error) {
+	return r.ResolveContext(context.Background(), modulePath, imports)
+}
+
+// ResolveContext is like Resolve but cancels proxy lookups when ctx is done.
+func (r *Resolver) ResolveContext(ctx context.Context, modulePath string, imports []string) ([]Requirement, error) {
+	versions := make(map[string]string)
+	var unresolved []string
+
+	for _, importPath := range imports {
+		if isStdlib(importPath) || importPath == modulePath || strings.HasPrefix(importPath, modulePath+"/") {
+			continue
+		}
+		if mod := modulePrefix(importPath, versions); mod != "" {
+			continue
+		}
+
+		mod, version, err := r.resolve(ctx, importPath)
+		if err != nil {
+			return nil, err
+		}
+		if mod == "" {
+			unresolved = append(unresolved, importPath)
+			continue
+		}
+		versions[mod] = version
+	}
+
+	if len(unresolved) > 0 {
+		return nil, fmt.Errorf("no module version known for %s; add it to the template's dependencies", strings.Join(unresolved, ", "))
+	}
+
+	requires := make([]Requirement, 0, len(versions))
+	for mod, version := range versions {
+		requires = append(requires, Requirement{Path: mod, Version: version})
+	}
+	sort.Slice(requires, func(i, j int) bool {
+		return requires[i].Path < requires[j].Path
+	})
+	return requires, nil
+}
+
+// resolve finds the module providing importPath and its version.
+func (r *Resolver) resolve(ctx context.Context, importPath string) (string, string, error) {
+	catalogMod := modulePrefix(importPath, r.Catalog)
+
+	if r.Proxy != "" {
+		candidates := []string{catalogMod}
+		if catalogMod == "" {
+			candidates = modulePathCandidates(importPath)
+		}
+		for _, mod := range candidates {
+			if version, err := r.latest(ctx, mod); err == nil {
+				return mod, version, nil
+			}
+			if err := ctx.Err(); err != nil {
+				return "", "", err
+			}
+		}
+	}
+
+	if catalogMod == "" {
+		return "", "", nil
+	}
+	return catalogMod, r.Catalog[catalogMod], nil
+}
+
+// latest asks the proxies for the latest version of mod.
+func (r *Resolver) latest(ctx context.Context, mod string) (string, error) {
+	escaped, err := escapeModulePath(mod)
+	if err != nil {
+		return "", err
+	}
+
+	client := r.Client
+	if client == nil {
+		client = defaultProxyClient()
+	}
+
+	lastErr := fmt.Errorf("no proxy configured")
+	for _, proxy := range strings.FieldsFunc(r.Proxy, func(r rune) bool { return r == ',' || r == '|' }) {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "direct" || proxy == "off" || proxy == "" {
+			continue
+		}
+
+		var info struct{ Version string }
+		lastErr = getJSON(ctx, client, strings.TrimSuffix(proxy, "/")+"/"+escaped+"/@latest", &info)
+		if lastErr == nil && info.Version != "" {
+			return info.Version, nil
+		}
+	}
+	return "", lastErr
+}
+
+func defaultProxyClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.RegisterProtocol("file", http.NewFileTransport(http.Dir("/")))
+	return &http.Client{Transport: transport, Timeout: 10 * time.Second}
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// modulePrefix returns the longest key of modules that is importPath or a
+// parent path of it.
+func modulePrefix[V any](importPath string, modules map[string]V) string {
+	for _, mod := range modulePathCandidates(importPath) {
+		if _, ok := modules[mod]; ok {
+			return mod
+		}
+	}
+	return ""
+}
+
+// modulePathCandidates returns importPath and its parent paths, longest
+// first, that could be module paths.
+func modulePathCandidates(importPath string) []string {
+	var candidates []string
+	for p := importPath; strings.Contains(p, "/"); p = path.Dir(p) {
+		candidates = append(candidates, p)
+	}
+	return candidates
+}
+
+// escapeModulePath applies the module proxy case encoding, replacing every
+// upper case letter with an exclamation mark followed by its lower case.
+func escapeModulePath(mod string) (string, error) {
+	var b strings.Builder
+	for _, r := range mod {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			b.WriteByte('!')
+			b.WriteRune(r + ('a' - 'A'))
+		case r == '!' || r > 0x7f:
+			return "", fmt.Errorf("invalid module path %q", mod)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String(), nil
+}
+
+// isStdlib reports whether importPath belongs to the standard library, whose
+// first path element never contains a dot.
+func isStdlib(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(first, ".")
+}
+
+// goImports returns the sorted, de-duplicated import paths of Go sources.
+func goImports(sources map[string]string) ([]string, error) {
+	seen := make(map[string]bool)
+	fset := token.NewFileSet()
+
+	for name, src := range sources {
+		// Syntax errors are left for the Go toolchain to report, the partial
+		// file still lists the imports that could be parsed.
+		file, _ := parser.ParseFile(fset, name, src, parser.ImportsOnly)
+		if file == nil {
+			continue
+		}
+		for _, spec := range file.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				return nil, err
+			}
+			seen[importPath] = true
+		}
+	}
+
+	imports := make([]string, 0, len(seen))
+	for importPath := range seen {
+		imports = append(imports, importPath)
+	}
+	sort.Strings(imports)
+	return imports, nil
+}
@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	resolver := &Resolver{Catalog: Catalog{
+		"github.com/spf13/cobra": "v1.9.1",
+		"google.golang.org/grpc": "v1.65.0",
+	}}
+
+	got, err := resolver.Resolve("example.com/svc", []string{
+		"fmt",
+		"net/http",
+		"example.com/svc/internal/handlers",
+		"github.com/spf13/cobra",
+		"google.golang.org/grpc",
+		"google.golang.org/grpc/codes",
+	})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	want := []Requirement{
+		{Path: "github.com/spf13/cobra", Version: "v1.9.1"},
+		{Path: "google.golang.org/grpc", Version: "v1.65.0"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Resolve() = %v, want %v", got, want)
+	}
+
+	if _, err := resolver.Resolve("example.com/svc", []string{"github.com/unknown/mod"}); err == nil {
+		t.Errorf("Resolve() of an unknown module error = nil, want error")
+	}
+}
+
+func TestResolver_ResolveFileProxy(t *testing.T) {
+	proxy := t.TempDir()
+	for mod, version := range map[string]string{
+		"github.com/spf13/cobra":       "v1.10.0",
+		"github.com/!burnt!sushi/toml": "v1.4.0",
+	} {
+		dir := filepath.Join(proxy, filepath.FromSlash(mod))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "@latest"), []byte(`{"Version":"`+version+`"}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resolver := &Resolver{
+		Catalog: Catalog{"github.com/spf13/cobra": "v1.9.1", "google.golang.org/grpc": "v1.65.0"},
+		Proxy:   "off,file://" + filepath.ToSlash(proxy),
+	}
+	got, err := resolver.Resolve("example.com/svc", []string{
+		"github.com/spf13/cobra",
+		"github.com/BurntSushi/toml",
+		"google.golang.org/grpc/status",
+	})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	want := []Requirement{
+		{Path: "github.com/BurntSushi/toml", Version: "v1.4.0"},
+		{Path: "github.com/spf13/cobra", Version: "v1.10.0"},
+		// Not on the proxy, falls back to the catalog.
+		{Path: "google.golang.org/grpc", Version: "v1.65.0"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Resolve() = %v, want %v", got, want)
+	}
+}
+
+func TestGenerator_GenerateRequirements(t *testing.T) {
+	catalog := DefaultCatalog()
+
+	tests := []struct {
+		projectType string
+		want        []string
+	}{
+		{projectType: "cli", want: []string{"github.com/spf13/cobra " + catalog["github.com/spf13/cobra"]}},
+		{projectType: "microservice", want: []string{"google.golang.org/grpc " + catalog["google.golang.org/grpc"]}},
+		{projectType: "web", want: []string{"gopkg.in/yaml.v3 " + catalog["gopkg.in/yaml.v3"]}},
+		{projectType: "tool"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.projectType, func(t *testing.T) {
+			projectPath := filepath.Join(t.TempDir(), "svc")
+			gen := New(ProjectConfig{
+				ProjectName: "svc",
+				ProjectPath: projectPath,
+				ProjectType: tt.projectType,
+			})
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			goMod, err := os.ReadFile(filepath.Join(projectPath, "go.mod"))
+			if err != nil {
+				t.Fatalf("Failed to read go.mod: %v", err)
+			}
+
+			var got []string
+			inRequire := false
+			for _, line := range strings.Split(string(goMod), "\n") {
+				switch {
+				case line == "require (":
+					inRequire = true
+				case line == ")":
+					inRequire = false
+				case inRequire:
+					got = append(got, strings.TrimSpace(line))
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("go.mod requires %v, want %v\n%s", got, tt.want, goMod)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Feature is an optional part of a generated project.
+type Feature struct {
+	Name        string
+	Description string
+}
+
+var features = []Feature{
+	{Name: "docker", Description: "Dockerfile building a minimal container image"},
+	{Name: "ci", Description: "GitHub Actions workflow running vet, build and test"},
+	{Name: "database", Description: "Database connection layer (web services)"},
+	{Name: "auth", Description: "Authentication middleware (web services)"},
+}
+
+// Features returns the optional features templates can be generated with.
+func Features() []Feature {
+	return append([]Feature(nil), features...)
+}
+
+// LicenseNone disables the license a project type generates by default.
+const LicenseNone = "none"
+
+// Licenses returns the SPDX identifiers of the licenses the generator can
+// add to a project.
+func Licenses() []string {
+	return []string{"MIT", "BSD-3-Clause", "ISC"}
+}
+
+// ValidateFeatures checks that every name is a known feature.
+func ValidateFeatures(names []string) error {
+	for _, name := range names {
+		known := false
+		for _, f := range features {
+			known = known || f.Name == name
+		}
+		if !known {
+			return fmt.Errorf("unknown feature %q", name)
+		}
+	}
+	return nil
+}
+
+// ValidateTypeFeatures checks that t supports every named feature.
+func ValidateTypeFeatures(t ProjectType, names []string) error {
+	return checkFeatures(t.Title(), t.Features(), names)
+}
+
+// checkFeatures checks that every name is one of the supported features of
+// the project type with the given title.
+func checkFeatures(title string, supported []Feature, names []string) error {
+	for _, name := range names {
+		if !slices.ContainsFunc(supported, func(f Feature) bool { return f.Name == name }) {
+			return fmt.Errorf("%s projects do not support the %q feature (supported: %s)", title, name, featureList(supported))
+		}
+	}
+	return nil
+}
+
+// featureList returns the names of features, comma separated, or none.
+func featureList(features []Feature) string {
+	if len(features) == 0 {
+		return "none"
+	}
+	names := make([]string, len(features))
+	for i, f := range features {
+		names[i] = f.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// supportedFeatures returns the features declared by the manifests of a
+// template's layers, or every feature when none of them declares any.
+func supportedFeatures(manifests []*Manifest) ([]Feature, error) {
+	declared := false
+	for _, manifest := range manifests {
+		if err := ValidateFeatures(manifest.Features); err != nil {
+			return nil, fmt.Errorf("%s: %w", ManifestFile, err)
+		}
+		declared = declared || manifest.Features != nil
+	}
+	if !declared {
+		return Features(), nil
+	}
+
+	var supported []Feature
+	for _, f := range features {
+		for _, manifest := range manifests {
+			if slices.Contains(manifest.Features, f.Name) {
+				supported = append(supported, f)
+				break
+			}
+		}
+	}
+	return supported, nil
+}
+
+// ValidateLicense checks that license is empty, LicenseNone or a supported
+// SPDX identifier, ignoring case, and returns its canonical spelling.
+func ValidateLicense(license string) (string, error) {
+	if license == "" || strings.EqualFold(license, LicenseNone) {
+		return strings.ToLower(license), nil
+	}
+	for _, known := range Licenses() {
+		if strings.EqualFold(license, known) {
+			return known, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported license %q (supported: %s, %s)", license, strings.Join(Licenses(), ", "), LicenseNone)
+}
@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerator_GenerateFeatures(t *testing.T) {
+	tests := []struct {
+		name        string
+		projectType string
+		features    []string
+		license     string
+		author      string
+		wantFiles   []string
+		noFiles     []string
+		wantLicense string
+	}{
+		{
+			name:        "web without features",
+			projectType: "web",
+			noFiles:     []string{"Dockerfile", ".dockerignore", ".github/workflows/ci.yml", "LICENSE"},
+		},
+		{
+			name:        "web with docker and ci",
+			projectType: "web",
+			features:    []string{"docker", "ci"},
+			license:     "bsd-3-clause",
+			author:      "Our Org",
+			wantFiles:   []string{"Dockerfile", ".dockerignore", ".github/workflows/ci.yml", "LICENSE"},
+			wantLicense: "BSD 3-Clause License\n\nCopyright (c) ",
+		},
+		{
+			name:        "library defaults to MIT",
+			projectType: "library",
+			features:    []string{"ci"},
+			wantFiles:   []string{"LICENSE", ".github/workflows/ci.yml"},
+			noFiles:     []string{"Dockerfile"},
+			wantLicense: "MIT License\n\nCopyright (c) ",
+		},
+		{
+			name:        "library without license",
+			projectType: "library",
+			license:     LicenseNone,
+			noFiles:     []string{"LICENSE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectPath := filepath.Join(t.TempDir(), "svc")
+			gen := New(ProjectConfig{
+				ProjectName: "svc",
+				ProjectPath: projectPath,
+				ProjectType: tt.projectType,
+				Features:    tt.features,
+				License:     tt.license,
+				Author:      tt.author,
+			})
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			for _, file := range tt.wantFiles {
+				if _, err := os.Stat(filepath.Join(projectPath, file)); err != nil {
+					t.Errorf("Expected file %s was not created", file)
+				}
+			}
+			for _, file := range tt.noFiles {
+				if _, err := os.Stat(filepath.Join(projectPath, file)); !os.IsNotExist(err) {
+					t.Errorf("Unexpected file %s was created", file)
+				}
+			}
+
+			if tt.wantLicense != "" {
+				license, err := os.ReadFile(filepath.Join(projectPath, "LICENSE"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				holder := tt.author
+				if holder == "" {
+					holder = "svc"
+				}
+				if !strings.HasPrefix(string(license), tt.wantLicense) || !strings.Contains(string(license), holder+"\n") {
+					t.Errorf("LICENSE =\n%s", license)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateInvalidOptions(t *testing.T) {
+	for _, config := range []ProjectConfig{
+		{Features: []string{"kubernetes"}},
+		{License: "GPL-3.0"},
+	} {
+		config.ProjectName = "svc"
+		config.ProjectPath = filepath.Join(t.TempDir(), "svc")
+		config.ProjectType = "web"
+		if err := New(config).Generate(); err == nil {
+			t.Errorf("Generate(%+v) error = nil, want error", config)
+		}
+	}
+}
+
+func TestGenerator_GenerateUnsupportedFeatures(t *testing.T) {
+	// A template that declares no features supports all of them.
+	templateDir := writeTemplateDir(t, map[string]string{
+		ManifestFile:   `{"name": "custom"}`,
+		"main.go.tmpl": "package main\n",
+	})
+
+	tests := []struct {
+		projectType, templateDir string
+		features                 []string
+		wantErr                  bool
+	}{
+		{"cli", "", []string{"docker", "ci"}, false},
+		{"cli", "", []string{"database"}, true},
+		{"cli", "", []string{"auth"}, true},
+		{"library", "", []string{"docker"}, true},
+		{"microservice", "", []string{"auth"}, true},
+		{"web", "", []string{"docker", "ci", "database", "auth"}, false},
+		{"cli", templateDir, []string{"database", "auth"}, false},
+	}
+	for _, tt := range tests {
+		out := NewMemFS()
+		err := New(ProjectConfig{
+			ProjectName: "svc",
+			ProjectPath: "svc",
+			ProjectType: tt.projectType,
+			TemplateDir: tt.templateDir,
+			Features:    tt.features,
+			Output:      out,
+		}).Generate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Generate() of %s with %v error = %v, wantErr %v", tt.projectType, tt.features, err, tt.wantErr)
+		}
+		if err != nil && len(out.Paths()) != 0 {
+			t.Errorf("Generate() of %s with %v wrote %v", tt.projectType, tt.features, out.Paths())
+		}
+	}
+}
+
+func TestValidateTypeFeatures(t *testing.T) {
+	library, _ := Lookup("library")
+	if err := ValidateTypeFeatures(library, []string{"ci"}); err != nil {
+		t.Errorf("ValidateTypeFeatures(library, ci) error = %v", err)
+	}
+	err := ValidateTypeFeatures(library, []string{"ci", "docker"})
+	if err == nil || !strings.Contains(err.Error(), `"docker"`) || !strings.Contains(err.Error(), "supported: ci") {
+		t.Errorf("ValidateTypeFeatures(library, ci,docker) error = %v, want docker unsupported", err)
+	}
+}
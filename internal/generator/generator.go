@@ -1,7 +1,11 @@
 package generator
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"text/template"
@@ -11,11 +15,64 @@ type ProjectConfig struct {
 	ProjectName string
 	ProjectPath string
 	ProjectType string
-	GitInit     bool
+	// ModulePath is the Go module path of the project. It defaults to the
+	// module segment derived from ProjectName.
+	ModulePath string
+	GitInit    bool
+	// Features lists the optional features to generate, see Features.
+	Features []string
+	// Options sets the options of the project type, see ProjectType.Options.
+	// List values are comma separated.
+	Options map[string]string
+	// License is the SPDX identifier of the project license, LicenseNone
+	// for no license, or empty for the project type's default.
+	License string
+	// Author is the copyright holder named in the license.
+	Author string
+	// GoVersion is the Go language version declared in go.mod. It defaults
+	// to DefaultGoVersion.
+	GoVersion string
+	// Dependencies maps modules to require in addition to the ones the
+	// generated code imports, to their version. An empty version is resolved
+	// from the catalog or GoProxy. Versions given here also pin the modules
+	// the templates import.
+	Dependencies map[string]string
+	// TemplateDir is a template directory on disk to render instead of the
+	// built-in scaffold for ProjectType.
+	TemplateDir string
+	// GoProxy is an optional GOPROXY style list of module proxies queried
+	// for the latest dependency versions instead of the built-in catalog.
+	GoProxy string
+	// Verify runs go mod tidy, vet, build and test in the generated project.
+	Verify bool
+	// Rollback removes the generated project if verification fails. A
+	// project directory that existed before generation is never removed.
+	Rollback bool
+	// DryRun records the operations generation would perform, available
+	// from Operations, without touching the file system.
+	DryRun bool
+	// OnConflict decides what happens to files that already exist in the
+	// project directory. By default a non-empty directory is an error.
+	OnConflict ConflictMode
+	// ResolveConflict is asked about every conflicting file when OnConflict
+	// is ConflictAsk.
+	ResolveConflict ConflictResolver
+	// Output receives the generated project instead of ProjectPath on disk,
+	// e.g. a MemFS or an archive. Existing files at ProjectPath are then
+	// ignored and Verify is not supported.
+	Output FS
 }
 
 type Generator struct {
 	Config ProjectConfig
+
+	ctx      context.Context
+	names    Names
+	ops      []Operation
+	warnings []string
+	// out is the file system files are written to, a staging directory or
+	// Config.Output while Generate runs. It defaults to Config.ProjectPath.
+	out FS
 }
 
 func New(config ProjectConfig) *Generator {
@@ -23,927 +80,270 @@ func New(config ProjectConfig) *Generator {
 }
 
 func (g *Generator) Generate() error {
-	switch g.Config.ProjectType {
-	case "cli":
-		return g.generateCLI()
-	case "web":
-		return g.generateWeb()
-	case "microservice":
-		return g.generateMicroservice()
-	case "library":
-		return g.generateLibrary()
-	case "tool":
-		return g.generateTool()
-	default:
-		return fmt.Errorf("unknown project type: %s", g.Config.ProjectType)
-	}
-}
-
-func (g *Generator) createDir(path string) error {
-	return os.MkdirAll(filepath.Join(g.Config.ProjectPath, path), 0755)
-}
-
-func (g *Generator) createFile(path, content string) error {
-	fullPath := filepath.Join(g.Config.ProjectPath, path)
-	dir := filepath.Dir(fullPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return err
-	}
-	return os.WriteFile(fullPath, []byte(content), 0644)
+	return g.GenerateContext(context.Background())
 }
 
-func (g *Generator) generateCLI() error {
-	// Create directory structure
-	dirs := []string{
-		"cmd",
-		"internal/commands",
-		"pkg",
-		"configs",
-		"scripts",
-	}
+// GenerateContext is like Generate but stops, leaving nothing behind, when
+// ctx is done.
+func (g *Generator) GenerateContext(ctx context.Context) error {
+	g.ctx = ctx
+	g.ops = nil
+	g.warnings = nil
 
-	for _, dir := range dirs {
-		if err := g.createDir(dir); err != nil {
-			return err
+	if g.Config.Output != nil {
+		if g.Config.Verify {
+			return errors.New("verify needs the project on disk and cannot be used with Output")
 		}
+		if g.Config.DryRun {
+			return g.generate()
+		}
+		g.out = g.Config.Output
+		defer func() { g.out = nil }()
+		return g.generate()
 	}
 
-	// Create main.go
-	mainContent := `package main
-
-import (
-	"fmt"
-	"os"
-
-	"{{.ProjectName}}/cmd"
-)
+	_, statErr := os.Stat(g.Config.ProjectPath)
+	existed := statErr == nil
 
-func main() {
-	if err := cmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
-	}
-}
-`
-	if err := g.createFileFromTemplate("main.go", mainContent, g.Config); err != nil {
+	if err := g.generateStaged(); err != nil {
 		return err
 	}
 
-	// Create cmd/root.go
-	rootContent := `package cmd
-
-import (
-	"fmt"
-	"os"
-
-	"github.com/spf13/cobra"
-)
-
-var rootCmd = &cobra.Command{
-	Use:   "{{.ProjectName}}",
-	Short: "A brief description of your CLI application",
-	Long:  "A longer description of your CLI application",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Welcome to {{.ProjectName}}!")
-		cmd.Help()
-	},
-}
-
-func Execute() error {
-	return rootCmd.Execute()
-}
-
-func init() {
-	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
-}
-`
-	if err := g.createFileFromTemplate("cmd/root.go", rootContent, g.Config); err != nil {
-		return err
+	if g.Config.Verify && !g.Config.DryRun {
+		if err := VerifyContext(ctx, g.Config.ProjectPath); err != nil {
+			if g.Config.Rollback && !existed {
+				if rmErr := os.RemoveAll(g.Config.ProjectPath); rmErr != nil {
+					return fmt.Errorf("%w (rollback failed: %v)", err, rmErr)
+				}
+			}
+			return err
+		}
 	}
 
-	// Create internal/commands/example.go
-	exampleCommand := `package commands
-
-import (
-	"fmt"
-)
-
-type ExampleCommand struct {
-	Name string
-}
-
-func NewExampleCommand(name string) *ExampleCommand {
-	return &ExampleCommand{Name: name}
-}
-
-func (c *ExampleCommand) Run() error {
-	fmt.Printf("Running example command with name: %s\n", c.Name)
 	return nil
 }
-`
-	if err := g.createFile("internal/commands/example.go", exampleCommand); err != nil {
-		return err
-	}
 
-	// Create go.mod
-	if err := g.createGoMod(); err != nil {
-		return err
+// generateStaged renders the project into a staging directory next to the
+// project path and moves the result into place only if rendering succeeded,
+// so that a failed run leaves nothing behind.
+func (g *Generator) generateStaged() error {
+	if g.Config.DryRun {
+		return g.generate()
 	}
 
-	// Create README
-	if err := g.createReadme("CLI"); err != nil {
+	parent := filepath.Dir(g.Config.ProjectPath)
+	createdParent, err := mkdirAllTracked(parent)
+	if err != nil {
 		return err
 	}
 
-	return nil
-}
-
-func (g *Generator) generateWeb() error {
-	// Create directory structure
-	dirs := []string{
-		"cmd/server",
-		"internal/handlers",
-		"internal/middleware",
-		"internal/models",
-		"internal/services",
-		"pkg/database",
-		"pkg/config",
-		"configs",
-		"scripts/migrations",
-		"api",
-	}
-
-	for _, dir := range dirs {
-		if err := g.createDir(dir); err != nil {
-			return err
-		}
+	staging, err := os.MkdirTemp(parent, "."+filepath.Base(g.Config.ProjectPath)+".generating-")
+	if err == nil {
+		// MkdirTemp creates the directory private to the user.
+		err = os.Chmod(staging, 0755)
 	}
-
-	// Create main.go
-	mainContent := `package main
-
-import (
-	"log"
-	"net/http"
-	"os"
-
-	"{{.ProjectName}}/internal/handlers"
-	"{{.ProjectName}}/internal/middleware"
-)
-
-func main() {
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
-	}
-
-	mux := http.NewServeMux()
-	
-	// Setup routes
-	mux.HandleFunc("/health", handlers.HealthHandler)
-	mux.HandleFunc("/api/v1/", handlers.APIHandler)
-	
-	// Apply middleware
-	handler := middleware.Logging(middleware.CORS(mux))
-	
-	log.Printf("Server starting on port %s", port)
-	if err := http.ListenAndServe(":"+port, handler); err != nil {
-		log.Fatal(err)
+	if err == nil {
+		g.out = DiskFS{Dir: staging}
+		err = g.generate()
+		g.out = nil
 	}
-}
-`
-	if err := g.createFileFromTemplate("main.go", mainContent, g.Config); err != nil {
-		return err
-	}
-
-	// Create handlers
-	healthHandler := `package handlers
-
-import (
-	"encoding/json"
-	"net/http"
-)
-
-func HealthHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{
-		"status": "healthy",
-	})
-}
-
-func APIHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{
-		"message": "API endpoint",
-		"version": "v1",
-	})
-}
-`
-	if err := g.createFile("internal/handlers/handlers.go", healthHandler); err != nil {
-		return err
+	if err == nil {
+		err = commitStaging(staging, g.Config.ProjectPath)
 	}
 
-	// Create middleware
-	middlewareContent := `package middleware
-
-import (
-	"log"
-	"net/http"
-	"time"
-)
-
-func Logging(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-		next.ServeHTTP(w, r)
-		log.Printf("%s %s %v", r.Method, r.URL.Path, time.Since(start))
-	})
-}
-
-func CORS(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
+	if err != nil {
+		if staging != "" {
+			_ = os.RemoveAll(staging)
+		}
+		if createdParent != "" {
+			_ = os.RemoveAll(createdParent)
 		}
-		
-		next.ServeHTTP(w, r)
-	})
-}
-`
-	if err := g.createFile("internal/middleware/middleware.go", middlewareContent); err != nil {
-		return err
-	}
-
-	// Create config.yaml
-	configContent := `server:
-  port: 8080
-  host: localhost
-
-database:
-  host: localhost
-  port: 5432
-  name: {{.ProjectName}}_db
-  user: postgres
-  password: password
-
-app:
-  name: {{.ProjectName}}
-  version: 1.0.0
-  environment: development
-`
-	if err := g.createFileFromTemplate("configs/config.yaml", configContent, g.Config); err != nil {
-		return err
-	}
-
-	// Create go.mod
-	if err := g.createGoMod(); err != nil {
-		return err
-	}
-
-	// Create README
-	if err := g.createReadme("Web Service"); err != nil {
 		return err
 	}
-
 	return nil
 }
 
-func (g *Generator) generateMicroservice() error {
-	// Create directory structure
-	dirs := []string{
-		"cmd/server",
-		"internal/service",
-		"internal/proto",
-		"pkg/interceptors",
-		"pkg/client",
-		"configs",
-		"scripts",
-		"api",
-	}
-
-	for _, dir := range dirs {
-		if err := g.createDir(dir); err != nil {
-			return err
+// mkdirAllTracked creates dir and any missing parents like os.MkdirAll and
+// returns the topmost directory it created, if any.
+func mkdirAllTracked(dir string) (string, error) {
+	created := ""
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(d); err == nil {
+			break
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		created = d
+		if filepath.Dir(d) == d {
+			break
 		}
 	}
 
-	// Create main.go
-	mainContent := `package main
-
-import (
-	"log"
-	"net"
-	"os"
-	"os/signal"
-	"syscall"
-
-	"google.golang.org/grpc"
-	"{{.ProjectName}}/internal/service"
-)
-
-func main() {
-	port := os.Getenv("GRPC_PORT")
-	if port == "" {
-		port = "50051"
-	}
-
-	lis, err := net.Listen("tcp", ":"+port)
-	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
-	}
-
-	grpcServer := grpc.NewServer()
-	
-	// Register your services here
-	svc := service.NewService()
-	// Example: pb.RegisterYourServiceServer(grpcServer, svc)
-	
-	// Graceful shutdown
-	go func() {
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-		<-sigChan
-		log.Println("Shutting down gRPC server...")
-		grpcServer.GracefulStop()
-	}()
-
-	log.Printf("gRPC server listening on port %s", port)
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
-	}
-}
-`
-	if err := g.createFileFromTemplate("main.go", mainContent, g.Config); err != nil {
-		return err
-	}
-
-	// Create service implementation
-	serviceContent := `package service
-
-import (
-	"context"
-	"log"
-)
-
-type Service struct {
-	// Add your service fields here
-}
-
-func NewService() *Service {
-	return &Service{}
-}
-
-// Implement your gRPC service methods here
-func (s *Service) ExampleMethod(ctx context.Context) error {
-	log.Println("ExampleMethod called")
-	return nil
-}
-`
-	if err := g.createFile("internal/service/service.go", serviceContent); err != nil {
-		return err
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
 	}
-
-	// Create proto file
-	protoContent := `syntax = "proto3";
-
-package {{.ProjectName}};
-
-option go_package = "{{.ProjectName}}/internal/proto";
-
-service ExampleService {
-    rpc ExampleMethod(ExampleRequest) returns (ExampleResponse);
-}
-
-message ExampleRequest {
-    string id = 1;
-    string data = 2;
+	return created, nil
 }
 
-message ExampleResponse {
-    string result = 1;
-    bool success = 2;
-}
-`
-	if err := g.createFileFromTemplate("internal/proto/service.proto", protoContent, g.Config); err != nil {
-		return err
+// commitStaging moves the staged project into place. A missing project
+// directory is replaced with a single rename, otherwise the staged files are
+// moved into the existing directory one by one.
+func commitStaging(staging, projectPath string) error {
+	if _, err := os.Stat(projectPath); errors.Is(err, fs.ErrNotExist) {
+		return os.Rename(staging, projectPath)
 	}
 
-	// Create proto generation script
-	protoGenScript := `#!/bin/bash
-
-# Generate Go code from proto files
-protoc --go_out=. --go_opt=paths=source_relative \
-    --go-grpc_out=. --go-grpc_opt=paths=source_relative \
-    internal/proto/*.proto
-
-echo "Proto files generated successfully"
-`
-	if err := g.createFile("scripts/proto-gen.sh", protoGenScript); err != nil {
-		err := os.Chmod(filepath.Join(g.Config.ProjectPath, "scripts/proto-gen.sh"), 0755)
+	err := filepath.WalkDir(staging, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		return err
-	}
-
-	// Make the script executable
-	scriptPath := filepath.Join(g.Config.ProjectPath, "scripts/proto-gen.sh")
-	if err := os.Chmod(scriptPath, 0755); err != nil {
-		// Log warning but don't fail - chmod might not work on all systems
-		fmt.Printf("Warning: Could not make proto-gen.sh executable: %v\n", err)
-	}
-
-	// Create go.mod with gRPC dependencies
-	if err := g.createGoModWithDeps([]string{
-		"google.golang.org/grpc",
-		"google.golang.org/protobuf",
-	}); err != nil {
-		return err
-	}
-
-	// Create README
-	if err := g.createReadme("Microservice"); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (g *Generator) generateLibrary() error {
-	// Create directory structure
-	dirs := []string{
-		"pkg/" + g.Config.ProjectName,
-		"examples",
-		"internal/helpers",
-		"scripts",
-		"docs",
-	}
-
-	for _, dir := range dirs {
-		if err := g.createDir(dir); err != nil {
+		rel, err := filepath.Rel(staging, path)
+		if err != nil {
 			return err
 		}
-	}
-
-	// Create main library file
-	libContent := `package {{.ProjectName}}
-
-import (
-	"fmt"
-)
-
-// Version represents the library version
-const Version = "1.0.0"
-
-// Config holds the library configuration
-type Config struct {
-	// Add your configuration fields here
-	Debug bool
-}
-
-// Client represents the main library client
-type Client struct {
-	config *Config
-}
-
-// New creates a new instance of the library client
-func New(config *Config) *Client {
-	if config == nil {
-		config = &Config{}
-	}
-	return &Client{config: config}
-}
-
-// ExampleMethod is an example public method
-func (c *Client) ExampleMethod(input string) (string, error) {
-	if input == "" {
-		return "", fmt.Errorf("input cannot be empty")
-	}
-	return fmt.Sprintf("Processed: %s", input), nil
-}
-`
-	libPath := fmt.Sprintf("pkg/%s/%s.go", g.Config.ProjectName, g.Config.ProjectName)
-	if err := g.createFileFromTemplate(libPath, libContent, g.Config); err != nil {
-		return err
-	}
-
-	// Create example usage
-	exampleContent := `package main
 
-import (
-	"fmt"
-	"log"
-
-	"{{.ProjectName}}/pkg/{{.ProjectName}}"
-)
-
-func main() {
-	// Create a new client
-	client := {{.ProjectName}}.New(&{{.ProjectName}}.Config{
-		Debug: true,
+		target := filepath.Join(projectPath, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		return os.Rename(path, target)
 	})
-
-	// Use the library
-	result, err := client.ExampleMethod("Hello, World!")
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
-
-	fmt.Println(result)
+	return os.RemoveAll(staging)
 }
-`
-	if err := g.createFileFromTemplate("examples/example.go", exampleContent, g.Config); err != nil {
+
+func (g *Generator) generate() error {
+	names, err := ParseName(g.Config.ProjectName)
+	if err != nil {
 		return err
 	}
+	g.names = names
 
-	// Create test file
-	testContent := `package {{.ProjectName}}_test
-
-import (
-	"testing"
-
-	"{{.ProjectName}}/pkg/{{.ProjectName}}"
-)
-
-func TestExampleMethod(t *testing.T) {
-	client := {{.ProjectName}}.New(nil)
-
-	tests := []struct {
-		name    string
-		input   string
-		want    string
-		wantErr bool
-	}{
-		{
-			name:    "valid input",
-			input:   "test",
-			want:    "Processed: test",
-			wantErr: false,
-		},
-		{
-			name:    "empty input",
-			input:   "",
-			want:    "",
-			wantErr: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := client.ExampleMethod(tt.input)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("ExampleMethod() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if got != tt.want {
-				t.Errorf("ExampleMethod() = %v, want %v", got, tt.want)
-			}
-		})
+	if err := ValidateFeatures(g.Config.Features); err != nil {
+		return err
 	}
-}
-`
-	testPath := fmt.Sprintf("pkg/%s/%s_test.go", g.Config.ProjectName, g.Config.ProjectName)
-	if err := g.createFileFromTemplate(testPath, testContent, g.Config); err != nil {
+	if g.Config.License, err = ValidateLicense(g.Config.License); err != nil {
 		return err
 	}
 
-	// Create LICENSE
-	licenseContent := `MIT License
-
-Copyright (c) 2024 {{.ProjectName}}
-
-Permission is hereby granted, free of charge, to any person obtaining a copy
-of this software and associated documentation files (the "Software"), to deal
-in the Software without restriction, including without limitation the rights
-to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
-copies of the Software, and to permit persons to whom the Software is
-furnished to do so, subject to the following conditions:
-
-The above copyright notice and this permission notice shall be included in all
-copies or substantial portions of the Software.
-
-THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
-IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
-FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
-AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
-LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
-OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
-SOFTWARE.
-`
-	if err := g.createFileFromTemplate("LICENSE", licenseContent, g.Config); err != nil {
+	if err := ValidateGoVersion(g.Config.GoVersion); err != nil {
 		return err
 	}
-
-	// Create go.mod
-	if err := g.createGoMod(); err != nil {
+	if err := ValidateDependencies(g.Config.Dependencies); err != nil {
 		return err
 	}
 
-	// Create README
-	if err := g.createReadme("Library"); err != nil {
+	if err := g.checkProjectDir(); err != nil {
 		return err
 	}
 
-	return nil
-}
-
-func (g *Generator) generateTool() error {
-	// Create directory structure
-	dirs := []string{
-		"cmd/commands",
-		"internal/utils",
-		"pkg",
-		"configs",
-		"scripts",
+	if g.Config.ModulePath != "" {
+		if err := ValidateModulePath(g.Config.ModulePath); err != nil {
+			return err
+		}
+	} else if _, err := names.ModulePath(""); err != nil {
+		return err
 	}
 
-	for _, dir := range dirs {
-		if err := g.createDir(dir); err != nil {
+	if g.Config.TemplateDir != "" {
+		layers, err := templateDirLayers(g.Config.TemplateDir)
+		if err != nil {
 			return err
 		}
+		return g.renderLayers(layers)
 	}
 
-	// Create main.go
-	mainContent := `package main
-
-import (
-	"flag"
-	"fmt"
-	"os"
-
-	"{{.ProjectName}}/cmd/commands"
-)
-
-func main() {
-	var (
-		verbose = flag.Bool("v", false, "verbose output")
-		help    = flag.Bool("h", false, "show help")
-	)
-
-	flag.Parse()
-
-	if *help || len(flag.Args()) == 0 {
-		printUsage()
-		os.Exit(0)
+	projectType, ok := Lookup(g.Config.ProjectType)
+	if !ok {
+		return fmt.Errorf("unknown project type: %s", g.Config.ProjectType)
 	}
+	g.Config.ProjectType = projectType.Name()
 
-	command := flag.Arg(0)
-	args := flag.Args()[1:]
+	return projectType.Render(g)
+}
 
-	switch command {
-	case "process":
-		cmd := commands.NewProcessCommand(*verbose)
-		if err := cmd.Run(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	case "analyze":
-		cmd := commands.NewAnalyzeCommand(*verbose)
-		if err := cmd.Run(args); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-	default:
-		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", command)
-		printUsage()
-		os.Exit(1)
+// output returns the file system generated files are written to.
+func (g *Generator) output() FS {
+	if g.out != nil {
+		return g.out
 	}
+	return DiskFS{Dir: g.Config.ProjectPath}
 }
 
-func printUsage() {
-	fmt.Println("Usage: {{.ProjectName}} [options] <command> [arguments]")
-	fmt.Println("\nCommands:")
-	fmt.Println("  process    Process input data")
-	fmt.Println("  analyze    Analyze input data")
-	fmt.Println("\nOptions:")
-	fmt.Println("  -v         Verbose output")
-	fmt.Println("  -h         Show this help message")
-}
-`
-	if err := g.createFileFromTemplate("main.go", mainContent, g.Config); err != nil {
-		return err
+// context returns the context of the running Generate call.
+func (g *Generator) context() context.Context {
+	if g.ctx == nil {
+		return context.Background()
 	}
-
-	// Create process command
-	processCmd := `package commands
-
-import (
-	"fmt"
-)
-
-type ProcessCommand struct {
-	verbose bool
+	return g.ctx
 }
 
-func NewProcessCommand(verbose bool) *ProcessCommand {
-	return &ProcessCommand{verbose: verbose}
+// Warnings returns the problems the last call to Generate ran into that did
+// not stop generation.
+func (g *Generator) Warnings() []string {
+	return append([]string(nil), g.warnings...)
 }
 
-func (c *ProcessCommand) Run(args []string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("no input provided")
-	}
-
-	if c.verbose {
-		fmt.Println("Processing input...")
-	}
-
-	for _, arg := range args {
-		fmt.Printf("Processing: %s\n", arg)
-		// Add your processing logic here
-	}
-
-	return nil
-}
-`
-	if err := g.createFile("cmd/commands/process.go", processCmd); err != nil {
+func (g *Generator) createDir(path string) error {
+	if err := g.context().Err(); err != nil {
 		return err
 	}
-
-	// Create analyze command
-	analyzeCmd := `package commands
-
-import (
-	"fmt"
-)
-
-type AnalyzeCommand struct {
-	verbose bool
+	g.record(Operation{Kind: OpDir, Path: filepath.ToSlash(path)})
+	if g.Config.DryRun {
+		return nil
+	}
+	return g.output().MkdirAll(filepath.ToSlash(path), 0755)
 }
 
-func NewAnalyzeCommand(verbose bool) *AnalyzeCommand {
-	return &AnalyzeCommand{verbose: verbose}
+func (g *Generator) createFile(path, content string) error {
+	return g.writeFile(path, content, 0644)
 }
 
-func (c *AnalyzeCommand) Run(args []string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("no input provided for analysis")
-	}
-
-	if c.verbose {
-		fmt.Println("Analyzing input...")
-	}
-
-	for _, arg := range args {
-		fmt.Printf("Analyzing: %s\n", arg)
-		// Add your analysis logic here
-	}
-
-	return nil
-}
-`
-	if err := g.createFile("cmd/commands/analyze.go", analyzeCmd); err != nil {
+func (g *Generator) writeFile(path, content string, perm fs.FileMode) error {
+	if err := g.context().Err(); err != nil {
 		return err
 	}
-
-	// Create utils
-	utilsContent := `package utils
-
-import (
-	"os"
-	"path/filepath"
-)
-
-// FileExists checks if a file exists
-func FileExists(path string) bool {
-	_, err := os.Stat(path)
-	return !os.IsNotExist(err)
-}
-
-// EnsureDir creates a directory if it doesn't exist
-func EnsureDir(path string) error {
-	return os.MkdirAll(path, 0755)
-}
-
-// GetExecutablePath returns the path of the current executable
-func GetExecutablePath() (string, error) {
-	ex, err := os.Executable()
+	keep, err := g.keepExisting(filepath.ToSlash(path), filepath.Join(g.Config.ProjectPath, path), content)
 	if err != nil {
-		return "", err
-	}
-	return filepath.Dir(ex), nil
-}
-`
-	if err := g.createFile("internal/utils/utils.go", utilsContent); err != nil {
 		return err
 	}
-
-	// Create go.mod
-	if err := g.createGoMod(); err != nil {
-		return err
+	if keep {
+		g.record(Operation{Kind: OpKeep, Path: filepath.ToSlash(path)})
+		return nil
 	}
 
-	// Create README
-	if err := g.createReadme("Tool"); err != nil {
-		return err
+	g.record(Operation{Kind: OpFile, Path: filepath.ToSlash(path), Mode: perm, Content: content})
+	if g.Config.DryRun {
+		return nil
 	}
 
-	return nil
-}
-
-func (g *Generator) createGoMod() error {
-	content := `module {{.ProjectName}}
-
-go 1.21
-
-require (
-	github.com/spf13/cobra v1.8.0
-)
-`
-	return g.createFileFromTemplate("go.mod", content, g.Config)
-}
-
-func (g *Generator) createGoModWithDeps(deps []string) error {
-	content := `module {{.ProjectName}}
-
-go 1.21
-
-require (
-`
-	for _, dep := range deps {
-		content += fmt.Sprintf("\t%s v1.65.0\n", dep)
+	out := g.output()
+	name := filepath.ToSlash(path)
+	if dir := filepath.Dir(path); dir != "." {
+		if err := out.MkdirAll(filepath.ToSlash(dir), 0755); err != nil {
+			return err
+		}
 	}
-	content += ")\n"
-
-	return g.createFileFromTemplate("go.mod", content, g.Config)
-}
-
-func (g *Generator) createReadme(projectType string) error {
-	content := `# {{.ProjectName}}
-
-## Description
-
-This is a {{.ProjectType}} project generated with go-project-generator.
-
-## Installation
-
-` + "```bash\ngo mod download\n```" + `
-
-## Usage
-
-### Running the application
-` + "```bash\ngo run main.go\n```" + `
-
-### Building
-` + "```bash\ngo build -o {{.ProjectName}}\n```" + `
-
-### Testing
-` + "```bash\ngo test ./...\n```" + `
-
-## Project Structure
-
-See the project structure for {{.ProjectType}} projects in the go-project-generator documentation.
-
-## Contributing
-
-1. Fork the repository
-2. Create your feature branch (` + "`git checkout -b feature/amazing-feature`" + `)
-3. Commit your changes (` + "`git commit -m 'Add some amazing feature'`" + `)
-4. Push to the branch (` + "`git push origin feature/amazing-feature`" + `)
-5. Open a Pull Request
-
-## License
-
-This project is licensed under the MIT License.
-`
-	data := struct {
-		ProjectName string
-		ProjectType string
-	}{
-		ProjectName: g.Config.ProjectName,
-		ProjectType: projectType,
+	err = out.WriteFile(name, []byte(content), perm)
+	if isChmodError(err) {
+		// Don't fail, chmod might not work on all systems.
+		g.warnings = append(g.warnings, fmt.Sprintf("could not set the permissions of %s: %v", name, err))
+		return nil
 	}
-
-	return g.createFileFromTemplate("README.md", content, data)
+	return err
 }
 
-func (g *Generator) createFileFromTemplate(path, templateContent string, data interface{}) error {
-	tmpl, err := template.New(path).Parse(templateContent)
+func renderTemplate(name, templateContent string, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Parse(templateContent)
 	if err != nil {
-		return err
-	}
-
-	fullPath := filepath.Join(g.Config.ProjectPath, path)
-	dir := filepath.Dir(fullPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return err
+		return "", err
 	}
 
-	file, err := os.Create(fullPath)
-	if err != nil {
-		return err
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
 	}
-	defer func(file *os.File) {
-		err := file.Close()
-		if err != nil {
-			fmt.Println(err)
-		}
-	}(file)
-
-	return tmpl.Execute(file, data)
+	return buf.String(), nil
 }
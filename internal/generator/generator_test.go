@@ -32,8 +32,10 @@ func TestGenerator_Generate(t *testing.T) {
 			wantErr:     false,
 			checkFiles: []string{
 				"main.go",
+				"main_test.go",
 				"internal/handlers/handlers.go",
 				"internal/middleware/middleware.go",
+				"pkg/config/config.go",
 				"configs/config.yaml",
 				"go.mod",
 				"README.md",
@@ -57,8 +59,8 @@ func TestGenerator_Generate(t *testing.T) {
 			projectType: "library",
 			wantErr:     false,
 			checkFiles: []string{
-				"pkg/test-project/test-project.go",
-				"pkg/test-project/test-project_test.go",
+				"pkg/testproject/testproject.go",
+				"pkg/testproject/testproject_test.go",
 				"examples/example.go",
 				"LICENSE",
 				"go.mod",
@@ -199,3 +201,51 @@ func TestGenerator_createFile(t *testing.T) {
 		t.Errorf("File content = %v, want %v", string(content), testContent)
 	}
 }
+
+func TestGenerator_GenerateAtomic(t *testing.T) {
+	templateDir := writeTemplateDir(t, map[string]string{
+		ManifestFile:      `{"name": "broken", "base": "microservice"}`,
+		"zz/last.go.tmpl": "package zz // {{.ProjectName\n",
+	})
+
+	tempDir := t.TempDir()
+	projectPath := filepath.Join(tempDir, "nested", "svc")
+
+	gen := New(ProjectConfig{
+		ProjectName: "svc",
+		ProjectPath: projectPath,
+		ProjectType: "microservice",
+		TemplateDir: templateDir,
+	})
+	if err := gen.Generate(); err == nil {
+		t.Fatalf("Generate() error = nil, want template error")
+	}
+
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Failed generation left %d entries behind, e.g. %s", len(entries), entries[0].Name())
+	}
+
+	gen.Config.TemplateDir = ""
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	info, err := os.Stat(projectPath)
+	if err != nil {
+		t.Fatalf("Project directory was not created: %v", err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("Project directory mode = %v, want 0755", info.Mode().Perm())
+	}
+
+	entries, err = os.ReadDir(filepath.Dir(projectPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Staging directory was left behind next to the project: %v", entries)
+	}
+}
@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultModulePath guesses the Go module path for a project generated at
+// projectPath. It uses, in order, the project's location under GOPATH/src,
+// the origin remote of an enclosing git repository, and finally the module
+// segment derived from the project name.
+func DefaultModulePath(projectPath, projectName string) string {
+	fallback := projectName
+	if names, err := ParseName(projectName); err == nil {
+		fallback = names.ModuleSegment
+	}
+
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return fallback
+	}
+
+	if modulePath, ok := gopathModulePath(absPath); ok {
+		return modulePath
+	}
+	if modulePath, ok := gitModulePath(absPath); ok {
+		return modulePath
+	}
+	return fallback
+}
+
+// ValidateModulePath reports whether modulePath is usable as a module path.
+func ValidateModulePath(modulePath string) error {
+	if modulePath == "" {
+		return fmt.Errorf("module path is empty")
+	}
+	if strings.HasPrefix(modulePath, "/") || strings.HasSuffix(modulePath, "/") {
+		return fmt.Errorf("module path %q must not begin or end with a slash", modulePath)
+	}
+	for _, elem := range strings.Split(modulePath, "/") {
+		if elem == "" || elem == "." || elem == ".." {
+			return fmt.Errorf("module path %q has an invalid element %q", modulePath, elem)
+		}
+		for _, r := range elem {
+			if !isModulePathChar(r) {
+				return fmt.Errorf("module path %q contains invalid character %q", modulePath, r)
+			}
+		}
+	}
+	return nil
+}
+
+func isModulePathChar(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		r == '-' || r == '.' || r == '_' || r == '~'
+}
+
+// gopathModulePath returns the import path of projectPath if it lies in the
+// src directory of a GOPATH entry.
+func gopathModulePath(projectPath string) (string, bool) {
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", false
+		}
+		gopath = filepath.Join(home, "go")
+	}
+
+	for _, root := range filepath.SplitList(gopath) {
+		src, err := filepath.Abs(filepath.Join(root, "src"))
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(src, projectPath)
+		if err != nil || rel == "." || !filepath.IsLocal(rel) {
+			continue
+		}
+		return filepath.ToSlash(rel), true
+	}
+	return "", false
+}
+
+// gitModulePath derives a module path from the origin remote of the git
+// repository enclosing projectPath, joined with the project's location
+// inside that repository.
+func gitModulePath(projectPath string) (string, bool) {
+	dir := existingParent(projectPath)
+	if dir == "" {
+		return "", false
+	}
+
+	root, err := gitOutput(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", false
+	}
+	remote, err := gitOutput(dir, "config", "--get", "remote.origin.url")
+	if err != nil {
+		return "", false
+	}
+
+	repoPath, ok := parseRemoteURL(remote)
+	if !ok {
+		return "", false
+	}
+
+	// Compare resolved paths, the git toplevel has its symlinks resolved.
+	resolvedDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", false
+	}
+	rest, err := filepath.Rel(dir, projectPath)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(root, filepath.Join(resolvedDir, rest))
+	if err != nil || !filepath.IsLocal(rel) {
+		return "", false
+	}
+	return path.Join(repoPath, filepath.ToSlash(rel)), true
+}
+
+// existingParent returns the closest existing directory containing path.
+func existingParent(path string) string {
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			return ""
+		}
+	}
+}
+
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// parseRemoteURL converts a git remote URL such as
+// https://github.com/org/repo.git or git@github.com:org/repo.git into the
+// module path github.com/org/repo.
+func parseRemoteURL(remote string) (string, bool) {
+	var host, repoPath string
+
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		switch u.Scheme {
+		case "http", "https", "ssh", "git":
+		default:
+			return "", false
+		}
+		host, repoPath = u.Hostname(), u.Path
+	} else if at := strings.Index(remote, "@"); at >= 0 && strings.Contains(remote[at:], ":") {
+		// scp-like syntax: user@host:org/repo.git
+		hostAndPath := remote[at+1:]
+		colon := strings.Index(hostAndPath, ":")
+		host, repoPath = hostAndPath[:colon], hostAndPath[colon+1:]
+	} else {
+		return "", false
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	if host == "" || repoPath == "" {
+		return "", false
+	}
+
+	modulePath := host + "/" + repoPath
+	if ValidateModulePath(modulePath) != nil {
+		return "", false
+	}
+	return modulePath, true
+}
@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		remote string
+		want   string
+		wantOK bool
+	}{
+		{remote: "https://github.com/ourorg/platform.git", want: "github.com/ourorg/platform", wantOK: true},
+		{remote: "https://user@gitlab.example.com:8443/team/svc", want: "gitlab.example.com/team/svc", wantOK: true},
+		{remote: "ssh://git@github.com/ourorg/platform.git", want: "github.com/ourorg/platform", wantOK: true},
+		{remote: "git@github.com:ourorg/platform.git", want: "github.com/ourorg/platform", wantOK: true},
+		{remote: "/srv/git/platform.git", wantOK: false},
+		{remote: "file:///srv/git/platform.git", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remote, func(t *testing.T) {
+			got, ok := parseRemoteURL(tt.remote)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseRemoteURL() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDefaultModulePath(t *testing.T) {
+	t.Run("GOPATH", func(t *testing.T) {
+		gopath := t.TempDir()
+		t.Setenv("GOPATH", gopath)
+
+		projectPath := filepath.Join(gopath, "src", "github.com", "ourorg", "svc")
+		if got := DefaultModulePath(projectPath, "svc"); got != "github.com/ourorg/svc" {
+			t.Errorf("DefaultModulePath() = %q, want %q", got, "github.com/ourorg/svc")
+		}
+	})
+
+	t.Run("git remote", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git is not installed")
+		}
+		t.Setenv("GOPATH", t.TempDir())
+
+		repo := t.TempDir()
+		for _, args := range [][]string{
+			{"init", "-q"},
+			{"remote", "add", "origin", "git@github.com:ourorg/platform.git"},
+		} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = repo
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("git %v: %v\n%s", args, err, out)
+			}
+		}
+
+		projectPath := filepath.Join(repo, "services", "billing")
+		if got := DefaultModulePath(projectPath, "billing"); got != "github.com/ourorg/platform/services/billing" {
+			t.Errorf("DefaultModulePath() = %q, want %q", got, "github.com/ourorg/platform/services/billing")
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		t.Setenv("GOPATH", t.TempDir())
+
+		projectPath := filepath.Join(t.TempDir(), "svc")
+		if got := DefaultModulePath(projectPath, "svc"); got != "svc" {
+			t.Errorf("DefaultModulePath() = %q, want %q", got, "svc")
+		}
+	})
+}
+
+func TestGenerator_GenerateModulePath(t *testing.T) {
+	for _, projectType := range Types() {
+		t.Run(projectType.Name(), func(t *testing.T) {
+			projectPath := filepath.Join(t.TempDir(), "svc")
+			gen := New(ProjectConfig{
+				ProjectName: "svc",
+				ProjectPath: projectPath,
+				ProjectType: projectType.Name(),
+				ModulePath:  "github.com/ourorg/svc",
+			})
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			err := filepath.WalkDir(projectPath, func(path string, d os.DirEntry, err error) error {
+				if err != nil || d.IsDir() {
+					return err
+				}
+				content, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				if filepath.Base(path) == "go.mod" && !strings.HasPrefix(string(content), "module github.com/ourorg/svc\n") {
+					t.Errorf("go.mod does not declare the module path:\n%s", content)
+				}
+				if strings.Contains(string(content), `"svc/`) {
+					t.Errorf("%s imports a path relative to the project name", path)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateInvalidModulePath(t *testing.T) {
+	gen := New(ProjectConfig{
+		ProjectName: "svc",
+		ProjectPath: filepath.Join(t.TempDir(), "svc"),
+		ProjectType: "cli",
+		ModulePath:  "github.com/our org/svc",
+	})
+	if err := gen.Generate(); err == nil {
+		t.Errorf("Generate() error = nil, want error")
+	}
+}
@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Names are the names derived from a project name, each valid in the
+// context it is used in.
+type Names struct {
+	// Project is the name as given. It names the project directory.
+	Project string
+	// Package is a Go package identifier, e.g. "govalidator".
+	Package string
+	// Binary is the name of the built executable, e.g. "go-validator".
+	Binary string
+	// ModuleSegment is the last element of the default module path, e.g.
+	// "go-validator". It is empty if the name has no ASCII letters or digits.
+	ModuleSegment string
+	// Display is a human readable name, e.g. "Go Validator".
+	Display string
+}
+
+// reservedFileNames cannot be used as file or directory names on Windows.
+var reservedFileNames = map[string]bool{
+	"con": true, "prn": true, "aux": true, "nul": true,
+	"com1": true, "com2": true, "com3": true, "com4": true, "com5": true,
+	"com6": true, "com7": true, "com8": true, "com9": true,
+	"lpt1": true, "lpt2": true, "lpt3": true, "lpt4": true, "lpt5": true,
+	"lpt6": true, "lpt7": true, "lpt8": true, "lpt9": true,
+}
+
+// ParseName validates a project name and derives the names used in
+// generated code from it. Names may contain letters, including non-ASCII
+// letters, digits, '-', '_' and '.'.
+func ParseName(name string) (Names, error) {
+	if strings.TrimSpace(name) == "" {
+		return Names{}, fmt.Errorf("invalid project name: name is empty")
+	}
+	if strings.ContainsAny(name, `/\`) {
+		return Names{}, fmt.Errorf("invalid project name %q: must not contain path separators", name)
+	}
+	if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "-") {
+		return Names{}, fmt.Errorf("invalid project name %q: must not begin with %q", name, name[:1])
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '.' {
+			return Names{}, fmt.Errorf("invalid project name %q: character %q is not allowed", name, r)
+		}
+	}
+	base, _, _ := strings.Cut(strings.ToLower(name), ".")
+	if reservedFileNames[base] {
+		return Names{}, fmt.Errorf("invalid project name %q: reserved file name", name)
+	}
+
+	names := Names{
+		Project:       name,
+		Package:       packageName(name),
+		Binary:        strings.ToLower(name),
+		ModuleSegment: moduleSegment(name),
+		Display:       displayName(name),
+	}
+	return names, nil
+}
+
+// ModulePath returns modulePath, or ModuleSegment if modulePath is empty. It
+// fails if both are empty, which is the case for names without characters a
+// module path can be derived from, e.g. "日本".
+func (n Names) ModulePath(modulePath string) (string, error) {
+	switch {
+	case modulePath != "":
+		return modulePath, nil
+	case n.ModuleSegment != "":
+		return n.ModuleSegment, nil
+	}
+	return "", fmt.Errorf("invalid project name %q: no module path can be derived, pass a module path explicitly", n.Project)
+}
+
+// packageName lowercases name and drops everything but ASCII letters and
+// digits, as the package also names a directory in import paths. Names that
+// start with a digit are prefixed and keywords get a suffix so that the
+// result is always a valid package identifier.
+func packageName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+
+	pkg := b.String()
+	switch {
+	case pkg == "":
+		return "project"
+	case unicode.IsDigit([]rune(pkg)[0]):
+		pkg = "x" + pkg
+	case token.IsKeyword(pkg) || pkg == "main":
+		pkg += "pkg"
+	}
+	return pkg
+}
+
+// moduleSegment lowercases name and keeps only the characters allowed in
+// module paths.
+func moduleSegment(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if isModulePathChar(r) {
+			b.WriteRune(r)
+		}
+	}
+	return strings.Trim(b.String(), "-._~")
+}
+
+// displayName splits name into words at '-', '_' and '.' and capitalizes
+// each word.
+func displayName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.'
+	})
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Names
+		wantErr bool
+	}{
+		{
+			name: "go-validator",
+			want: Names{Project: "go-validator", Package: "govalidator", Binary: "go-validator", ModuleSegment: "go-validator", Display: "Go Validator"},
+		},
+		{
+			name: "User_API",
+			want: Names{Project: "User_API", Package: "userapi", Binary: "user_api", ModuleSegment: "user_api", Display: "User API"},
+		},
+		{
+			name: "3d-engine",
+			want: Names{Project: "3d-engine", Package: "x3dengine", Binary: "3d-engine", ModuleSegment: "3d-engine", Display: "3d Engine"},
+		},
+		{
+			name: "type",
+			want: Names{Project: "type", Package: "typepkg", Binary: "type", ModuleSegment: "type", Display: "Type"},
+		},
+		{
+			name: "main",
+			want: Names{Project: "main", Package: "mainpkg", Binary: "main", ModuleSegment: "main", Display: "Main"},
+		},
+		{
+			name: "café-api",
+			want: Names{Project: "café-api", Package: "cafapi", Binary: "café-api", ModuleSegment: "caf-api", Display: "Café Api"},
+		},
+		{
+			name: "日本",
+			want: Names{Project: "日本", Package: "project", Binary: "日本", ModuleSegment: "", Display: "日本"},
+		},
+		{name: "", wantErr: true},
+		{name: "my project", wantErr: true},
+		{name: "org/project", wantErr: true},
+		{name: `org\project`, wantErr: true},
+		{name: "..", wantErr: true},
+		{name: ".hidden", wantErr: true},
+		{name: "-flag", wantErr: true},
+		{name: "con", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseName(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseName() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateNameWithoutModuleSegment(t *testing.T) {
+	config := ProjectConfig{ProjectName: "日本", ProjectPath: "日本", ProjectType: "cli", Output: NewMemFS()}
+	if err := New(config).Generate(); err == nil || !strings.Contains(err.Error(), "pass a module path explicitly") {
+		t.Errorf("Generate() without a module path error = %v, want a module path error", err)
+	}
+
+	out := NewMemFS()
+	config.ModulePath = "example.com/nihon"
+	config.Output = out
+	if err := New(config).Generate(); err != nil {
+		t.Fatalf("Generate() with a module path error = %v", err)
+	}
+	if goMod, _ := out.ReadFile("go.mod"); !strings.HasPrefix(string(goMod), "module example.com/nihon\n") {
+		t.Errorf("go.mod = %q, want module example.com/nihon", goMod)
+	}
+}
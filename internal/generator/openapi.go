@@ -0,0 +1,1024 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// API is an OpenAPI 3 document, read for an option of type OptionOpenAPI, in
+// the shape templates need to generate code for it. Go types of the models
+// are unqualified in Models and qualified with "models." in Operations.
+type API struct {
+	Title       string
+	Version     string
+	Description string
+	// Source is the document as read.
+	Source string
+	// Models are the schemas of the components section, in document order.
+	Models []APIModel
+	// Operations are sorted by path and method.
+	Operations []APIOperation
+	// ModelImports lists the standard library packages the models use.
+	ModelImports []string
+	// OperationImports lists the standard library packages the operation
+	// signatures use besides context.
+	OperationImports []string
+	// OperationsUseModels reports whether operation signatures use models.
+	OperationsUseModels bool
+	// HasBodies, HasPathParams and HasQueryParams report whether any
+	// operation has a request body, path parameters or query parameters.
+	HasBodies      bool
+	HasPathParams  bool
+	HasQueryParams bool
+}
+
+// APIModel is a component schema.
+type APIModel struct {
+	// Name is the Go type name.
+	Name string
+	// Doc holds the lines of the schema description.
+	Doc []string
+	// Struct reports whether the schema is an object with properties. Other
+	// schemas become a defined type of Type.
+	Struct bool
+	Type   string
+	Fields []APIField
+	// Validates reports whether the model has a Validate method, which all
+	// structs and other models with constraints have.
+	Validates bool
+	// Receiver is the receiver name of the Validate method.
+	Receiver string
+	// Checks are the statements of the Validate method, one line each,
+	// indented for the method body.
+	Checks []string
+}
+
+// APIField is a property of an object schema.
+type APIField struct {
+	Name     string
+	JSONName string
+	Type     string
+	Required bool
+	// Doc holds the lines of the property description.
+	Doc []string
+	// Decl is the field declaration with its struct tag, aligned with the
+	// fields around it the way gofmt aligns them.
+	Decl string
+}
+
+// APIOperation is an operation of a path.
+type APIOperation struct {
+	// Name is the Go method name, from the operationId if there is one.
+	Name string
+	// Method is the HTTP method in upper case and MethodName in title case,
+	// e.g. GET and Get.
+	Method     string
+	MethodName string
+	Path       string
+	Summary    string
+	// ChiPath is Path with wildcard names usable with net/http and chi, and
+	// MuxPath is the net/http pattern path, which does not match subtrees.
+	ChiPath string
+	MuxPath string
+	// ColonPath is Path in the :name form of gin and echo.
+	ColonPath   string
+	PathParams  []APIParam
+	QueryParams []APIParam
+	// Body is the request body, nil if there is none.
+	Body *APIBody
+	// Status is the status of a successful response as a Go expression,
+	// e.g. http.StatusOK.
+	Status string
+	// Response is the Go type of the successful response body, empty if it
+	// has none.
+	Response string
+	// ResponseZero is the zero value of Response.
+	ResponseZero string
+	// ExamplePath is Path with example values for the path parameters and
+	// the required query parameters, for tests.
+	ExamplePath string
+	// InvalidPath is ExamplePath with an invalid value for the first
+	// non-string path parameter, empty if there is none.
+	InvalidPath string
+}
+
+// APIParam is a path or query parameter.
+type APIParam struct {
+	// Name is the parameter name in the document.
+	Name string
+	// Wildcard is the name of the path wildcard.
+	Wildcard string
+	// Var is the Go variable name.
+	Var string
+	// Type is the Go type: string, int32, int64, float32, float64 or bool.
+	Type     string
+	Required bool
+}
+
+// APIBody is a JSON request body.
+type APIBody struct {
+	// Type is the qualified Go type of the model.
+	Type     string
+	Required bool
+}
+
+// OptionOpenAPI is the Type of options whose value is the path of an OpenAPI
+// 3 document. Templates read the document as {{.API}}.
+const OptionOpenAPI = "openapi"
+
+// loadOptionAPI reads the OpenAPI document of the first option of type
+// OptionOpenAPI that is set. It returns nil if there is none.
+func loadOptionAPI(options []Option, values map[string]string) (*API, error) {
+	for _, option := range options {
+		if option.Type == OptionOpenAPI && values[option.Name] != "" {
+			return LoadAPI(values[option.Name])
+		}
+	}
+	return nil, nil
+}
+
+// checkRoutes returns an error if an operation of api collides with one of
+// routes, the "[METHOD ]/path" patterns the project serves besides the
+// operations. Like in net/http, a route without a method takes all of them.
+func (api *API) checkRoutes(routes []string) error {
+	for _, op := range api.Operations {
+		for _, route := range routes {
+			method, path, ok := strings.Cut(route, " ")
+			if !ok {
+				method, path = op.Method, route
+			}
+			if op.Method == method && pathsConflict(op.MuxPath, path) {
+				return fmt.Errorf("%s %s conflicts with the built-in route %s", op.Method, op.Path, route)
+			}
+		}
+	}
+	return nil
+}
+
+// pathsConflict reports whether routers refuse to serve both net/http pattern
+// paths for the same method: they are the same up to the names of their
+// wildcards, or, which gin rejects, they have differently named wildcards in
+// the same place.
+func pathsConflict(a, b string) bool {
+	as, bs := strings.Split(a, "/"), strings.Split(b, "/")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		aWildcard, bWildcard := isWildcard(as[i]), isWildcard(bs[i])
+		switch {
+		case aWildcard && bWildcard:
+			if as[i] != bs[i] {
+				return true
+			}
+		case as[i] != bs[i]:
+			return false
+		}
+	}
+	return len(as) == len(bs)
+}
+
+// isWildcard reports whether the path segment is a net/http wildcard other
+// than {$}.
+func isWildcard(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") && segment != "{$}"
+}
+
+// LoadAPI reads the OpenAPI 3 document at path, in YAML or JSON. Schemas may
+// only reference schemas in the components section, and parameters must be
+// path or query parameters of a scalar type.
+func LoadAPI(path string) (*API, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("openapi: %w", err)
+	}
+	api, err := parseAPI(data)
+	if err != nil {
+		return nil, fmt.Errorf("openapi: %s: %w", path, err)
+	}
+	return api, nil
+}
+
+// The openAPI types hold the parts of an OpenAPI 3 document the generator
+// uses. Everything else is ignored.
+type (
+	openAPIDocument struct {
+		OpenAPI string `yaml:"openapi"`
+		Info    struct {
+			Title       string `yaml:"title"`
+			Version     string `yaml:"version"`
+			Description string `yaml:"description"`
+		} `yaml:"info"`
+		Paths      ordered[openAPIPathItem] `yaml:"paths"`
+		Components struct {
+			Schemas ordered[*openAPISchema] `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+
+	openAPIPathItem struct {
+		Parameters []openAPIParameter `yaml:"parameters"`
+		Get        *openAPIOperation  `yaml:"get"`
+		Put        *openAPIOperation  `yaml:"put"`
+		Post       *openAPIOperation  `yaml:"post"`
+		Delete     *openAPIOperation  `yaml:"delete"`
+		Patch      *openAPIOperation  `yaml:"patch"`
+	}
+
+	openAPIOperation struct {
+		OperationID string                   `yaml:"operationId"`
+		Summary     string                   `yaml:"summary"`
+		Parameters  []openAPIParameter       `yaml:"parameters"`
+		RequestBody *openAPIRequestBody      `yaml:"requestBody"`
+		Responses   ordered[openAPIResponse] `yaml:"responses"`
+	}
+
+	openAPIParameter struct {
+		Ref      string         `yaml:"$ref"`
+		Name     string         `yaml:"name"`
+		In       string         `yaml:"in"`
+		Required bool           `yaml:"required"`
+		Schema   *openAPISchema `yaml:"schema"`
+	}
+
+	openAPIRequestBody struct {
+		Ref      string                      `yaml:"$ref"`
+		Required bool                        `yaml:"required"`
+		Content  map[string]openAPIMediaType `yaml:"content"`
+	}
+
+	openAPIResponse struct {
+		Ref     string                      `yaml:"$ref"`
+		Content map[string]openAPIMediaType `yaml:"content"`
+	}
+
+	openAPIMediaType struct {
+		Schema *openAPISchema `yaml:"schema"`
+	}
+
+	openAPISchema struct {
+		Ref         string                  `yaml:"$ref"`
+		Type        string                  `yaml:"type"`
+		Format      string                  `yaml:"format"`
+		Description string                  `yaml:"description"`
+		Properties  ordered[*openAPISchema] `yaml:"properties"`
+		Required    []string                `yaml:"required"`
+		Items       *openAPISchema          `yaml:"items"`
+		Enum        []yaml.Node             `yaml:"enum"`
+		MinLength   *int                    `yaml:"minLength"`
+		MaxLength   *int                    `yaml:"maxLength"`
+		Minimum     *float64                `yaml:"minimum"`
+		Maximum     *float64                `yaml:"maximum"`
+	}
+)
+
+// ordered is a YAML mapping that remembers the order of its keys.
+type ordered[V any] struct {
+	keys   []string
+	values map[string]V
+}
+
+func (o *ordered[V]) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: want a mapping", node.Line)
+	}
+	o.values = make(map[string]V)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var v V
+		if err := node.Content[i+1].Decode(&v); err != nil {
+			return err
+		}
+		key := node.Content[i].Value
+		o.keys = append(o.keys, key)
+		o.values[key] = v
+	}
+	return nil
+}
+
+const schemaRefPrefix = "#/components/schemas/"
+
+// apiParser converts a document into an API.
+type apiParser struct {
+	names        map[string]string
+	structs      map[string]bool
+	scalars      map[string]bool
+	validates    map[string]bool
+	modelImports map[string]bool
+}
+
+func parseAPI(data []byte) (*API, error) {
+	var doc openAPIDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported version %q: want an OpenAPI 3 document", doc.OpenAPI)
+	}
+
+	p := &apiParser{
+		names:        make(map[string]string),
+		structs:      make(map[string]bool),
+		scalars:      make(map[string]bool),
+		validates:    make(map[string]bool),
+		modelImports: make(map[string]bool),
+	}
+	api := &API{
+		Title:       doc.Info.Title,
+		Version:     doc.Info.Version,
+		Description: doc.Info.Description,
+		Source:      string(data),
+	}
+
+	// Name every schema first so that schemas can reference later ones.
+	goNames := make(map[string]string)
+	for _, name := range doc.Components.Schemas.keys {
+		goName := exportedName(name)
+		if other, ok := goNames[goName]; ok {
+			return nil, fmt.Errorf("schemas %s and %s have the same Go name %s", other, name, goName)
+		}
+		goNames[goName] = name
+		p.names[name] = goName
+		schema := doc.Components.Schemas.values[name]
+		p.structs[name] = schema != nil && schema.Ref == "" && schema.Properties.keys != nil
+		p.scalars[name] = schema != nil && schema.Ref == "" && isScalarType(schema.Type)
+		p.validates[name] = p.structs[name] || hasConstraints(schema)
+	}
+
+	for _, name := range doc.Components.Schemas.keys {
+		model, err := p.model(name, doc.Components.Schemas.values[name])
+		if err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+		api.Models = append(api.Models, model)
+	}
+	api.ModelImports = sortedKeys(p.modelImports)
+
+	operationImports := make(map[string]bool)
+	opNames := make(map[string]string)
+	paths := append([]string(nil), doc.Paths.keys...)
+	sort.Strings(paths)
+	for _, path := range paths {
+		item := doc.Paths.values[path]
+		for _, method := range []struct {
+			name string
+			op   *openAPIOperation
+		}{
+			{"GET", item.Get}, {"POST", item.Post}, {"PUT", item.Put}, {"PATCH", item.Patch}, {"DELETE", item.Delete},
+		} {
+			if method.op == nil {
+				continue
+			}
+			op, err := p.operation(method.name, path, item.Parameters, method.op)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method.name, path, err)
+			}
+			if other, ok := opNames[op.Name]; ok {
+				return nil, fmt.Errorf("%s %s and %s have the same Go name %s", method.name, path, other, op.Name)
+			}
+			opNames[op.Name] = method.name + " " + path
+
+			for _, t := range []string{op.Response, bodyType(op.Body)} {
+				if strings.Contains(t, "models.") {
+					api.OperationsUseModels = true
+				}
+				if strings.Contains(t, "time.") {
+					operationImports["time"] = true
+				}
+			}
+			api.HasBodies = api.HasBodies || op.Body != nil
+			api.HasPathParams = api.HasPathParams || len(op.PathParams) > 0
+			api.HasQueryParams = api.HasQueryParams || len(op.QueryParams) > 0
+			api.Operations = append(api.Operations, op)
+		}
+	}
+	api.OperationImports = sortedKeys(operationImports)
+	return api, nil
+}
+
+func bodyType(body *APIBody) string {
+	if body == nil {
+		return ""
+	}
+	return body.Type
+}
+
+// model converts the component schema name.
+func (p *apiParser) model(name string, schema *openAPISchema) (APIModel, error) {
+	if schema == nil {
+		return APIModel{}, fmt.Errorf("schema is empty")
+	}
+	model := APIModel{Name: p.names[name], Doc: docLines(schema.Description), Validates: p.validates[name]}
+	first, _ := utf8.DecodeRuneInString(model.Name)
+	model.Receiver = string(unicode.ToLower(first))
+	if model.Receiver == "i" {
+		// i is the index in the checks of slices.
+		model.Receiver = "m"
+	}
+
+	if !p.structs[name] {
+		t, err := p.goType(schema, "")
+		if err != nil {
+			return model, err
+		}
+		model.Type = t
+
+		expr := model.Receiver
+		if t == "string" {
+			expr = "string(" + expr + ")"
+		}
+		if model.Checks, err = p.checks(expr, "value", schema, false, false); err != nil {
+			return model, err
+		}
+		if len(model.Checks) > 0 {
+			p.modelImports["errors"] = true
+		}
+		return model, nil
+	}
+
+	model.Struct = true
+	fieldNames := make(map[string]bool)
+	for _, prop := range schema.Properties.keys {
+		propSchema := schema.Properties.values[prop]
+		if propSchema == nil {
+			return model, fmt.Errorf("property %s: schema is empty", prop)
+		}
+		required := containsString(schema.Required, prop)
+
+		t, err := p.goType(propSchema, "")
+		if err != nil {
+			return model, fmt.Errorf("property %s: %w", prop, err)
+		}
+		pointer := !required && p.isScalar(propSchema)
+		if pointer {
+			t = "*" + t
+		}
+
+		field := APIField{
+			Name:     exportedName(prop),
+			JSONName: prop,
+			Type:     t,
+			Required: required,
+			Doc:      docLines(propSchema.Description),
+		}
+		if fieldNames[field.Name] {
+			return model, fmt.Errorf("two properties have the Go name %s", field.Name)
+		}
+		fieldNames[field.Name] = true
+		model.Fields = append(model.Fields, field)
+
+		checks, err := p.checks(model.Receiver+"."+field.Name, prop, propSchema, required, pointer)
+		if err != nil {
+			return model, fmt.Errorf("property %s: %w", prop, err)
+		}
+		model.Checks = append(model.Checks, checks...)
+	}
+	if len(model.Checks) > 0 {
+		p.modelImports["errors"] = true
+	}
+	alignFields(model.Fields)
+	return model, nil
+}
+
+// alignFields sets the Decl of fields. Like gofmt, it aligns the names,
+// types and tags of consecutive fields, a doc comment ending the run.
+func alignFields(fields []APIField) {
+	for start := 0; start < len(fields); {
+		end := start + 1
+		for end < len(fields) && len(fields[end].Doc) == 0 {
+			end++
+		}
+
+		var nameWidth, typeWidth int
+		for _, field := range fields[start:end] {
+			nameWidth = max(nameWidth, utf8.RuneCountInString(field.Name))
+			typeWidth = max(typeWidth, utf8.RuneCountInString(field.Type))
+		}
+		for i := start; i < end; i++ {
+			tag := fields[i].JSONName
+			if !fields[i].Required {
+				tag += ",omitempty"
+			}
+			fields[i].Decl = fmt.Sprintf("%s%s %s%s `json:%q`",
+				fields[i].Name, strings.Repeat(" ", nameWidth-utf8.RuneCountInString(fields[i].Name)),
+				fields[i].Type, strings.Repeat(" ", typeWidth-utf8.RuneCountInString(fields[i].Type)),
+				tag)
+		}
+		start = end
+	}
+}
+
+// docLines splits a description into comment lines.
+func docLines(description string) []string {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return nil
+	}
+	lines := strings.Split(description, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+	}
+	return lines
+}
+
+// isScalar reports whether schema becomes a struct or a Go type with a
+// meaningful zero value, which optional properties use a pointer to.
+func (p *apiParser) isScalar(schema *openAPISchema) bool {
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, schemaRefPrefix)
+		return p.structs[name] || p.scalars[name]
+	}
+	return isScalarType(schema.Type)
+}
+
+func isScalarType(t string) bool {
+	switch t {
+	case "string", "integer", "number", "boolean":
+		return true
+	}
+	return false
+}
+
+// hasConstraints reports whether the values of schema, which is not an
+// object with properties, are checked by a Validate method.
+func hasConstraints(schema *openAPISchema) bool {
+	switch {
+	case schema == nil || schema.Ref != "":
+		return false
+	case schema.Type == "string":
+		return len(schema.Enum) > 0 || schema.MinLength != nil || schema.MaxLength != nil
+	case schema.Type == "integer" || schema.Type == "number":
+		return schema.Minimum != nil || schema.Maximum != nil
+	case schema.Type == "array":
+		return schema.Items != nil && schema.Items.Ref != ""
+	}
+	return false
+}
+
+// checks returns the validation statements for the property name of a model
+// at the Go expression expr.
+func (p *apiParser) checks(expr, name string, schema *openAPISchema, required, pointer bool) ([]string, error) {
+	var checks []string
+	fail := func(indent, format string, args ...any) string {
+		return fmt.Sprintf("%s\terrs = append(errs, errors.New(%q))", indent, fmt.Sprintf(format, args...))
+	}
+
+	if schema.Ref != "" {
+		if !p.validates[strings.TrimPrefix(schema.Ref, schemaRefPrefix)] {
+			return nil, nil
+		}
+		p.modelImports["fmt"] = true
+		validate := []string{
+			fmt.Sprintf("\tif err := %s.Validate(); err != nil {", expr),
+			fmt.Sprintf("\t\terrs = append(errs, fmt.Errorf(\"%s: %%w\", err))", name),
+			"\t}",
+		}
+		if pointer {
+			return append(append([]string{fmt.Sprintf("\tif %s != nil {", expr)}, indent(validate)...), "\t}"), nil
+		}
+		return validate, nil
+	}
+
+	if schema.Type == "array" && schema.Items != nil && schema.Items.Ref != "" && p.validates[strings.TrimPrefix(schema.Items.Ref, schemaRefPrefix)] {
+		p.modelImports["fmt"] = true
+		return []string{
+			fmt.Sprintf("\tfor i, item := range %s {", expr),
+			"\t\tif err := item.Validate(); err != nil {",
+			fmt.Sprintf("\t\t\terrs = append(errs, fmt.Errorf(\"%s[%%d]: %%w\", i, err))", name),
+			"\t\t}",
+			"\t}",
+		}, nil
+	}
+
+	// Constraints on optional values only apply when they are set.
+	value, guard := expr, ""
+	if pointer {
+		value, guard = "*"+expr, expr+" != nil && "
+	}
+
+	switch {
+	case schema.Type == "string" && schema.Format != "date-time":
+		if required {
+			checks = append(checks, fmt.Sprintf("\tif %s == \"\" {", value), fail("\t", "%s is required", name), "\t}")
+			guard = value + " != \"\" && "
+		}
+		if len(schema.Enum) > 0 {
+			var values, quoted []string
+			for _, node := range schema.Enum {
+				values = append(values, node.Value)
+				quoted = append(quoted, strconv.Quote(node.Value))
+			}
+			if guard != "" {
+				checks = append(checks, fmt.Sprintf("\tif %s {", strings.TrimSuffix(guard, " && ")))
+			}
+			inner := []string{
+				fmt.Sprintf("\tswitch %s {", value),
+				fmt.Sprintf("\tcase %s:", strings.Join(quoted, ", ")),
+				"\tdefault:",
+				fail("\t", "%s must be one of %s", name, strings.Join(values, ", ")),
+				"\t}",
+			}
+			if guard != "" {
+				inner = append(indent(inner), "\t}")
+			}
+			checks = append(checks, inner...)
+		}
+		if schema.MinLength != nil {
+			p.modelImports["unicode/utf8"] = true
+			checks = append(checks,
+				fmt.Sprintf("\tif %sutf8.RuneCountInString(%s) < %d {", guard, value, *schema.MinLength),
+				fail("\t", "%s must be at least %d characters long", name, *schema.MinLength),
+				"\t}")
+		}
+		if schema.MaxLength != nil {
+			p.modelImports["unicode/utf8"] = true
+			checks = append(checks,
+				fmt.Sprintf("\tif %sutf8.RuneCountInString(%s) > %d {", guard, value, *schema.MaxLength),
+				fail("\t", "%s must be at most %d characters long", name, *schema.MaxLength),
+				"\t}")
+		}
+	case schema.Type == "integer" || schema.Type == "number":
+		bound := func(v float64, round func(float64) float64) string {
+			if schema.Type == "integer" {
+				v = round(v)
+			}
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		if schema.Minimum != nil {
+			min := bound(*schema.Minimum, math.Ceil)
+			checks = append(checks,
+				fmt.Sprintf("\tif %s%s < %s {", guard, value, min),
+				fail("\t", "%s must be at least %s", name, min),
+				"\t}")
+		}
+		if schema.Maximum != nil {
+			max := bound(*schema.Maximum, math.Floor)
+			checks = append(checks,
+				fmt.Sprintf("\tif %s%s > %s {", guard, value, max),
+				fail("\t", "%s must be at most %s", name, max),
+				"\t}")
+		}
+	}
+	return checks, nil
+}
+
+// indent indents lines by one more tab.
+func indent(lines []string) []string {
+	indented := make([]string, len(lines))
+	for i, line := range lines {
+		indented[i] = "\t" + line
+	}
+	return indented
+}
+
+// goType returns the Go type of schema, qualifying model types with pkg.
+func (p *apiParser) goType(schema *openAPISchema, pkg string) (string, error) {
+	if schema == nil {
+		return "any", nil
+	}
+	if schema.Ref != "" {
+		name, ok := strings.CutPrefix(schema.Ref, schemaRefPrefix)
+		if !ok {
+			return "", fmt.Errorf("unsupported reference %s: want %sName", schema.Ref, schemaRefPrefix)
+		}
+		goName, ok := p.names[name]
+		if !ok {
+			return "", fmt.Errorf("reference to unknown schema %s", name)
+		}
+		return pkg + goName, nil
+	}
+
+	switch schema.Type {
+	case "string":
+		if schema.Format == "date-time" {
+			if pkg == "" {
+				p.modelImports["time"] = true
+			}
+			return "time.Time", nil
+		}
+		return "string", nil
+	case "integer":
+		if schema.Format == "int32" {
+			return "int32", nil
+		}
+		return "int64", nil
+	case "number":
+		if schema.Format == "float" {
+			return "float32", nil
+		}
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if schema.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		items, err := p.goType(schema.Items, pkg)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + items, nil
+	case "object":
+		return "map[string]any", nil
+	case "":
+		if schema.Properties.keys != nil {
+			return "map[string]any", nil
+		}
+		return "any", nil
+	default:
+		return "", fmt.Errorf("unsupported type %q", schema.Type)
+	}
+}
+
+// operation converts the operation of method at path.
+func (p *apiParser) operation(method, path string, pathParams []openAPIParameter, op *openAPIOperation) (APIOperation, error) {
+	result := APIOperation{
+		Name:       exportedName(op.OperationID),
+		Method:     method,
+		MethodName: method[:1] + strings.ToLower(method[1:]),
+		Path:       path,
+		Summary:    strings.Join(strings.Fields(op.Summary), " "),
+	}
+	if op.OperationID == "" {
+		result.Name = exportedName(strings.ToLower(method) + " " + path)
+	}
+
+	// Operation parameters override path item parameters with the same name
+	// and location.
+	params := make(map[string]openAPIParameter)
+	var keys []string
+	for _, param := range append(append([]openAPIParameter(nil), pathParams...), op.Parameters...) {
+		if param.Ref != "" {
+			return result, fmt.Errorf("parameter references are not supported: %s", param.Ref)
+		}
+		key := param.In + " " + param.Name
+		if _, ok := params[key]; !ok {
+			keys = append(keys, key)
+		}
+		params[key] = param
+	}
+
+	// Parameters must not shadow the names the handlers use.
+	vars := make(map[string]bool)
+	for _, name := range []string{"a", "body", "ctx", "err", "r", "resp", "w",
+		"context", "errors", "fmt", "http", "io", "json", "log", "models", "strconv", "strings", "time"} {
+		vars[name] = true
+	}
+	muxPath, colonPath, examplePath, invalidPath := path, path, path, ""
+	var query []string
+	for _, key := range keys {
+		param := params[key]
+		t, err := p.paramType(param.Schema)
+		if err != nil {
+			return result, fmt.Errorf("parameter %s: %w", param.Name, err)
+		}
+
+		v := unexportedName(param.Name)
+		for vars[v] || isGoKeyword(v) {
+			v += "Param"
+		}
+		vars[v] = true
+		apiParam := APIParam{Name: param.Name, Var: v, Type: t, Required: param.Required || param.In == "path"}
+
+		example := map[string]string{"string": "example", "bool": "true"}[t]
+		if example == "" {
+			example = "1"
+		}
+
+		switch param.In {
+		case "path":
+			placeholder := "{" + param.Name + "}"
+			if !strings.Contains(path, placeholder) {
+				return result, fmt.Errorf("path parameter %s is not in the path", param.Name)
+			}
+			apiParam.Wildcard = wildcardName(param.Name)
+			muxPath = strings.ReplaceAll(muxPath, placeholder, "{"+apiParam.Wildcard+"}")
+			colonPath = strings.ReplaceAll(colonPath, placeholder, ":"+apiParam.Wildcard)
+			if t != "string" && invalidPath == "" {
+				invalidPath = strings.ReplaceAll(examplePath, placeholder, "invalid")
+			}
+			examplePath = strings.ReplaceAll(examplePath, placeholder, example)
+			if invalidPath != "" {
+				invalidPath = strings.ReplaceAll(invalidPath, placeholder, example)
+			}
+			result.PathParams = append(result.PathParams, apiParam)
+		case "query":
+			if apiParam.Required {
+				query = append(query, param.Name+"="+example)
+			}
+			result.QueryParams = append(result.QueryParams, apiParam)
+		default:
+			return result, fmt.Errorf("parameter %s: %s parameters are not supported", param.Name, param.In)
+		}
+	}
+	if strings.Contains(muxPath, "{") && strings.Count(muxPath, "{") != len(result.PathParams) {
+		return result, fmt.Errorf("the path has parameters that are not declared")
+	}
+	result.ChiPath, result.MuxPath, result.ColonPath = muxPath, muxPath, colonPath
+	if strings.HasSuffix(muxPath, "/") {
+		// A trailing slash would match the whole subtree.
+		result.MuxPath += "{$}"
+	}
+	if len(query) > 0 {
+		examplePath += "?" + strings.Join(query, "&")
+		if invalidPath != "" {
+			invalidPath += "?" + strings.Join(query, "&")
+		}
+	}
+	result.ExamplePath, result.InvalidPath = examplePath, invalidPath
+
+	if body := op.RequestBody; body != nil {
+		if body.Ref != "" {
+			return result, fmt.Errorf("request body references are not supported: %s", body.Ref)
+		}
+		media, ok := body.Content["application/json"]
+		if !ok || media.Schema == nil || !p.structs[strings.TrimPrefix(media.Schema.Ref, schemaRefPrefix)] {
+			return result, fmt.Errorf("the request body must be application/json referencing an object schema in %s", strings.TrimSuffix(schemaRefPrefix, "/"))
+		}
+		t, err := p.goType(media.Schema, "models.")
+		if err != nil {
+			return result, fmt.Errorf("request body: %w", err)
+		}
+		result.Body = &APIBody{Type: t, Required: body.Required}
+	}
+
+	result.Status = statusExpr(http.StatusOK)
+	for _, code := range op.Responses.keys {
+		status, err := strconv.Atoi(code)
+		if err != nil || status < 200 || status > 299 {
+			continue
+		}
+		result.Status = statusExpr(status)
+		response := op.Responses.values[code]
+		if response.Ref != "" {
+			return result, fmt.Errorf("response references are not supported: %s", response.Ref)
+		}
+		if media, ok := response.Content["application/json"]; ok && status != 204 {
+			t, err := p.goType(media.Schema, "models.")
+			if err != nil {
+				return result, fmt.Errorf("response %s: %w", code, err)
+			}
+			result.Response, result.ResponseZero = t, zeroValue(t)
+		}
+		break
+	}
+	return result, nil
+}
+
+// successStatuses are the net/http constants of the 2xx statuses.
+var successStatuses = map[int]string{
+	http.StatusOK:                   "http.StatusOK",
+	http.StatusCreated:              "http.StatusCreated",
+	http.StatusAccepted:             "http.StatusAccepted",
+	http.StatusNonAuthoritativeInfo: "http.StatusNonAuthoritativeInfo",
+	http.StatusNoContent:            "http.StatusNoContent",
+	http.StatusResetContent:         "http.StatusResetContent",
+	http.StatusPartialContent:       "http.StatusPartialContent",
+	http.StatusMultiStatus:          "http.StatusMultiStatus",
+	http.StatusAlreadyReported:      "http.StatusAlreadyReported",
+	http.StatusIMUsed:               "http.StatusIMUsed",
+}
+
+// statusExpr returns the net/http constant for status, or status itself if
+// there is none.
+func statusExpr(status int) string {
+	if name, ok := successStatuses[status]; ok {
+		return name
+	}
+	return strconv.Itoa(status)
+}
+
+// paramType returns the Go type of a parameter schema.
+func (p *apiParser) paramType(schema *openAPISchema) (string, error) {
+	if schema == nil {
+		return "string", nil
+	}
+	t, err := p.goType(schema, "")
+	if err != nil {
+		return "", err
+	}
+	switch t {
+	case "string", "int32", "int64", "float32", "float64", "bool":
+		return t, nil
+	}
+	return "", fmt.Errorf("unsupported parameter type %s: want a string, integer, number or boolean", t)
+}
+
+// zeroValue returns the zero value of the Go type t.
+func zeroValue(t string) string {
+	switch {
+	case t == "string":
+		return `""`
+	case t == "bool":
+		return "false"
+	case strings.HasPrefix(t, "int"), strings.HasPrefix(t, "float"):
+		return "0"
+	case strings.HasPrefix(t, "[]"), strings.HasPrefix(t, "map["), strings.HasPrefix(t, "*"), t == "any":
+		return "nil"
+	default:
+		return t + "{}"
+	}
+}
+
+// commonInitialisms are written in upper case in Go names.
+var commonInitialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true,
+	"JSON": true, "SQL": true, "TLS": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// nameWords splits name into words at characters other than letters and
+// digits and where a lower case letter is followed by an upper case one.
+func nameWords(name string) []string {
+	var (
+		words []string
+		word  []rune
+	)
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+	for _, r := range name {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && len(word) > 0 && unicode.IsLower(word[len(word)-1]):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+	return words
+}
+
+// exportedName returns an exported Go identifier for name, e.g. PetID for
+// petId or pet_id.
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, word := range nameWords(name) {
+		if upper := strings.ToUpper(word); commonInitialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		runes := []rune(word)
+		b.WriteString(string(unicode.ToUpper(runes[0])) + string(runes[1:]))
+	}
+	if b.Len() == 0 || unicode.IsDigit([]rune(b.String())[0]) {
+		return "X" + b.String()
+	}
+	return b.String()
+}
+
+// unexportedName returns an unexported Go identifier for name, e.g. petID
+// for pet_id.
+func unexportedName(name string) string {
+	words := nameWords(name)
+	if len(words) == 0 || unicode.IsDigit([]rune(words[0])[0]) {
+		return "x" + exportedName(name)
+	}
+	first := strings.ToLower(words[0])
+	return first + strings.TrimPrefix(exportedName(name), exportedName(words[0]))
+}
+
+// wildcardName returns a path wildcard name for the parameter name that is
+// valid in net/http patterns.
+func wildcardName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	if b.Len() == 0 || unicode.IsDigit([]rune(b.String())[0]) {
+		return "p" + b.String()
+	}
+	return b.String()
+}
+
+func isGoKeyword(name string) bool {
+	switch name {
+	case "break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for",
+		"func", "go", "goto", "if", "import", "interface", "map", "package", "range", "return", "select",
+		"struct", "switch", "type", "var":
+		return true
+	}
+	return false
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
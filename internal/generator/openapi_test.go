@@ -0,0 +1,399 @@
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testOpenAPI = `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            format: int32
+      responses:
+        "200":
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/Pet"
+    post:
+      operationId: createPet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Pet"
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+  /pets/{pet-id}:
+    delete:
+      parameters:
+        - name: pet-id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "204":
+          description: Deleted
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+          format: int64
+        name:
+          type: string
+          description: The name of the pet.
+          maxLength: 20
+        tag:
+          type: string
+          enum: [dog, cat]
+        owner:
+          $ref: "#/components/schemas/Owner"
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+func TestParseAPI(t *testing.T) {
+	api, err := parseAPI([]byte(testOpenAPI))
+	if err != nil {
+		t.Fatalf("parseAPI() error = %v", err)
+	}
+
+	if len(api.Models) != 2 || api.Models[0].Name != "Pet" || api.Models[1].Name != "Owner" {
+		t.Fatalf("Models = %+v, want Pet and Owner in document order", api.Models)
+	}
+	var decls []string
+	for _, field := range api.Models[0].Fields {
+		decls = append(decls, field.Decl)
+	}
+	wantDecls := []string{
+		"ID int64 `json:\"id\"`",
+		"Name  string  `json:\"name\"`",
+		"Tag   *string `json:\"tag,omitempty\"`",
+		"Owner *Owner  `json:\"owner,omitempty\"`",
+	}
+	if !reflect.DeepEqual(decls, wantDecls) {
+		t.Errorf("Pet fields = %q, want %q", decls, wantDecls)
+	}
+	checks := strings.Join(api.Models[0].Checks, "\n")
+	for _, want := range []string{`p.Name == ""`, `case "dog", "cat":`, "utf8.RuneCountInString(p.Name) > 20", "p.Owner.Validate()"} {
+		if !strings.Contains(checks, want) {
+			t.Errorf("Pet checks do not contain %s:\n%s", want, checks)
+		}
+	}
+	if want := []string{"errors", "fmt", "unicode/utf8"}; !reflect.DeepEqual(api.ModelImports, want) {
+		t.Errorf("ModelImports = %v, want %v", api.ModelImports, want)
+	}
+
+	var ops []string
+	for _, op := range api.Operations {
+		ops = append(ops, strings.Join([]string{op.Name, op.Method, op.MuxPath, op.ColonPath, op.Status, op.Response}, " "))
+	}
+	wantOps := []string{
+		"ListPets GET /pets /pets http.StatusOK []models.Pet",
+		"CreatePet POST /pets /pets http.StatusCreated models.Pet",
+		"DeletePetsPetID DELETE /pets/{pet_id} /pets/:pet_id http.StatusNoContent ",
+	}
+	if !reflect.DeepEqual(ops, wantOps) {
+		t.Errorf("Operations = %q, want %q", ops, wantOps)
+	}
+	if want := (APIParam{Name: "limit", Var: "limit", Type: "int32"}); !reflect.DeepEqual(api.Operations[0].QueryParams, []APIParam{want}) {
+		t.Errorf("ListPets query parameters = %+v, want %+v", api.Operations[0].QueryParams, want)
+	}
+	if got := api.Operations[2].InvalidPath; got != "/pets/invalid" {
+		t.Errorf("DeletePetsPetID InvalidPath = %q, want /pets/invalid", got)
+	}
+
+	// Receivers take the first character, not the first byte, of the name.
+	api, err = parseAPI([]byte("openapi: 3.0.3\ninfo:\n  title: T\n  version: v1\ncomponents:\n  schemas:\n    Ünit:\n      type: string\n      minLength: 1\n"))
+	if err != nil {
+		t.Fatalf("parseAPI() error = %v", err)
+	}
+	if len(api.Models) != 1 || api.Models[0].Name != "Ünit" || api.Models[0].Receiver != "ü" {
+		t.Errorf("Models = %+v, want Ünit with receiver ü", api.Models)
+	}
+}
+
+func TestParseAPIErrors(t *testing.T) {
+	const header = "openapi: 3.1.0\ninfo:\n  title: T\n  version: v1\n"
+
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{
+			name:    "swagger 2",
+			doc:     "swagger: \"2.0\"\n",
+			wantErr: "want an OpenAPI 3 document",
+		},
+		{
+			name:    "external reference",
+			doc:     header + "components:\n  schemas:\n    A:\n      $ref: other.yaml#/B\n",
+			wantErr: "unsupported reference other.yaml#/B",
+		},
+		{
+			name:    "unknown schema",
+			doc:     header + "components:\n  schemas:\n    A:\n      type: array\n      items:\n        $ref: '#/components/schemas/B'\n",
+			wantErr: "reference to unknown schema B",
+		},
+		{
+			name:    "header parameter",
+			doc:     header + "paths:\n  /a:\n    get:\n      parameters:\n        - name: X-Key\n          in: header\n      responses: {}\n",
+			wantErr: "header parameters are not supported",
+		},
+		{
+			name:    "undeclared path parameter",
+			doc:     header + "paths:\n  /a/{id}:\n    get:\n      responses: {}\n",
+			wantErr: "parameters that are not declared",
+		},
+		{
+			name:    "form body",
+			doc:     header + "paths:\n  /a:\n    post:\n      requestBody:\n        content:\n          application/x-www-form-urlencoded: {}\n      responses: {}\n",
+			wantErr: "the request body must be application/json",
+		},
+		{
+			name:    "duplicate operation names",
+			doc:     header + "paths:\n  /a:\n    get:\n      operationId: getA\n      responses: {}\n  /b:\n    get:\n      operationId: get_a\n      responses: {}\n",
+			wantErr: "the same Go name GetA",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseAPI([]byte(tt.doc))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseAPI() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExportedName(t *testing.T) {
+	tests := map[string]string{
+		"petId":          "PetID",
+		"pet_id":         "PetID",
+		"get /pets/{id}": "GetPetsID",
+		"apiURL":         "APIURL",
+		"HTTPServer":     "HTTPServer",
+		"2fa":            "X2fa",
+	}
+	for name, want := range tests {
+		if got := exportedName(name); got != want {
+			t.Errorf("exportedName(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	if got := unexportedName("Pet-ID"); got != "petID" {
+		t.Errorf("unexportedName(Pet-ID) = %q, want petID", got)
+	}
+}
+
+func TestGenerator_GenerateWebOpenAPI(t *testing.T) {
+	spec := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(spec, []byte(testOpenAPI), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		router string
+		want   string
+	}{
+		{router: "stdlib", want: `mux.HandleFunc("DELETE /pets/{pet_id}", operations.serveDeletePetsPetID)`},
+		{router: "chi", want: `r.Delete("/pets/{pet_id}", operations.serveDeletePetsPetID)`},
+		{router: "gin", want: `r.Handle(http.MethodDelete, "/pets/:pet_id", ginHandler(operations.serveDeletePetsPetID))`},
+		{router: "echo", want: `e.Add(http.MethodDelete, "/pets/:pet_id", echoHandler(operations.serveDeletePetsPetID))`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.router, func(t *testing.T) {
+			out := generateWeb(t, map[string]string{"router": tt.router, "openapi": spec}, nil)
+
+			files := []string{
+				"internal/models/api.go",
+				"internal/handlers/api.go",
+				"internal/handlers/api_test.go",
+				"internal/handlers/openapi.go",
+				"internal/handlers/handlers.go",
+				"internal/handlers/handlers_test.go",
+				"internal/handlers/routes.go",
+			}
+			for _, file := range files {
+				if _, err := fs.Stat(out, file); err != nil {
+					t.Errorf("Expected file %s was not created", file)
+				}
+			}
+			checkFormatted(t, out, files)
+
+			routes, _ := out.ReadFile("internal/handlers/routes.go")
+			if !strings.Contains(string(routes), tt.want) {
+				t.Errorf("routes.go does not contain %s:\n%s", tt.want, routes)
+			}
+			if strings.Contains(string(routes), "APIHandler") {
+				t.Errorf("routes.go still serves the placeholder APIHandler:\n%s", routes)
+			}
+			doc, _ := out.ReadFile("api/openapi.yaml")
+			if string(doc) != testOpenAPI {
+				t.Errorf("api/openapi.yaml = %q, want the document", doc)
+			}
+		})
+	}
+
+	t.Run("without document", func(t *testing.T) {
+		out := generateWeb(t, nil, nil)
+		for _, file := range []string{"api/openapi.yaml", "internal/models/api.go", "internal/handlers/api.go", "internal/handlers/openapi.go"} {
+			if _, err := fs.Stat(out, file); err == nil {
+				t.Errorf("Unexpected file %s was created", file)
+			}
+		}
+	})
+
+	t.Run("missing document", func(t *testing.T) {
+		gen := New(ProjectConfig{
+			ProjectName: "svc",
+			ProjectPath: "svc",
+			ProjectType: "web",
+			ModulePath:  "example.com/svc",
+			Options:     map[string]string{"openapi": filepath.Join(t.TempDir(), "missing.yaml")},
+			Output:      NewMemFS(),
+		})
+		if err := gen.Generate(); err == nil || !strings.Contains(err.Error(), "openapi:") {
+			t.Errorf("Generate() error = %v, want an openapi error", err)
+		}
+	})
+}
+
+// writeRouteSpec writes an OpenAPI document with an operation for every
+// "METHOD /path" route and returns its path.
+func writeRouteSpec(t *testing.T, routes ...string) string {
+	t.Helper()
+
+	var b strings.Builder
+	b.WriteString("openapi: 3.0.3\ninfo:\n  title: Routes\n  version: 1.0.0\npaths:\n")
+	for _, route := range routes {
+		method, path, _ := strings.Cut(route, " ")
+		fmt.Fprintf(&b, "  %s:\n    %s:\n", path, strings.ToLower(method))
+		if _, param, ok := strings.Cut(path, "{"); ok {
+			param, _, _ = strings.Cut(param, "}")
+			fmt.Fprintf(&b, "      parameters:\n        - name: %s\n          in: path\n          required: true\n          schema:\n            type: string\n", param)
+		}
+		b.WriteString("      responses:\n        \"204\":\n          description: Done\n")
+	}
+
+	spec := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(spec, []byte(b.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return spec
+}
+
+func TestPathsConflict(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"/health", "/health", true},
+		{"/items/{id}", "/items/{id}", true},
+		{"/items/{item}", "/items/{id}", true},
+		{"/items/{item}/tags", "/items/{id}", true},
+		{"/items/{id}/tags", "/items/{id}", false},
+		{"/items/new", "/items/{id}", false},
+		{"/items/{$}", "/items", false},
+		{"/health/", "/health", false},
+		{"/pets", "/items", false},
+	}
+	for _, tt := range tests {
+		if got := pathsConflict(tt.a, tt.b); got != tt.want {
+			t.Errorf("pathsConflict(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestGenerator_GenerateWebOpenAPIRoutes(t *testing.T) {
+	tests := []struct {
+		route   string
+		options map[string]string
+		wantErr string
+	}{
+		{route: "GET /health", wantErr: "GET /health conflicts with the built-in route /health"},
+		{route: "POST /health", wantErr: "POST /health conflicts with the built-in route /health"},
+		{route: "GET /health/ready"},
+		{route: "GET /api/v1/items"},
+		{route: "GET /api/v1/items", options: map[string]string{"db": "sqlite"}, wantErr: "built-in route GET /api/v1/items"},
+		{route: "GET /api/v1/items/{item}", options: map[string]string{"db": "sqlite"}, wantErr: "built-in route GET /api/v1/items/{id}"},
+		{route: "GET /api/v1/items/{id}/tags", options: map[string]string{"db": "sqlite"}},
+		{route: "POST /api/v1/login", options: map[string]string{"auth": "jwt"}, wantErr: "built-in route POST /api/v1/login"},
+		{route: "POST /api/v1/login", options: map[string]string{"auth": "apikey"}},
+		{route: "GET /api/v1/me", options: map[string]string{"auth": "apikey"}, wantErr: "built-in route GET /api/v1/me"},
+		{route: "POST /api/v1/logout", options: map[string]string{"auth": "jwt"}},
+		{route: "POST /api/v1/logout", options: map[string]string{"auth": "session"}, wantErr: "built-in route POST /api/v1/logout"},
+	}
+
+	for _, tt := range tests {
+		options := map[string]string{"openapi": writeRouteSpec(t, tt.route)}
+		for name, value := range tt.options {
+			options[name] = value
+		}
+		err := New(ProjectConfig{
+			ProjectName: "svc",
+			ProjectPath: "svc",
+			ProjectType: "web",
+			ModulePath:  "example.com/svc",
+			Options:     options,
+			Output:      NewMemFS(),
+		}).Generate()
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("Generate() with %s and %v error = %v", tt.route, tt.options, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("Generate() with %s and %v error = %v, want %q", tt.route, tt.options, err, tt.wantErr)
+		}
+	}
+}
+
+func TestGenerator_GenerateWebOpenAPIVerify(t *testing.T) {
+	// The operations sit next to the built-in routes without colliding.
+	spec := writeRouteSpec(t, "GET /health/ready", "GET /api/v1/items/{id}/tags", "PUT /api/v1/me")
+	for _, router := range []string{"stdlib", "gin"} {
+		t.Run(router, func(t *testing.T) {
+			generateVerified(t, ProjectConfig{
+				ProjectName: "svc",
+				ProjectPath: filepath.Join(t.TempDir(), "svc"),
+				ProjectType: "web",
+				ModulePath:  "example.com/svc",
+				Options:     map[string]string{"router": router, "db": "sqlite", "auth": "session", "openapi": spec},
+			})
+		})
+	}
+}
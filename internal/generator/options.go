@@ -0,0 +1,149 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Option is a setting specific to a project type, declared in the options of
+// its manifest, e.g. the database of a web service. Templates read the
+// selected value as {{.Options.name}}.
+type Option struct {
+	// Name identifies the option, e.g. "db".
+	Name string `json:"name"`
+	// Description is shown in the command line help.
+	Description string `json:"description"`
+	// Values lists the allowed values. Any value is allowed if it is empty.
+	Values []string `json:"values"`
+	// Default is the value used when the option is not set. For list options
+	// it is a comma separated list.
+	Default string `json:"default"`
+	// List allows a comma separated list of values.
+	List bool `json:"list"`
+	// Feature ties the option to an optional feature: setting the option
+	// enables the feature, and the feature alone selects Default. Without
+	// either the option is empty. An option explicitly set to the empty
+	// string is always empty.
+	Feature string `json:"feature"`
+	// Type is the kind of value the option takes. Options of type
+	// OptionOpenAPI name an OpenAPI document. Other options are plain strings.
+	Type string `json:"type"`
+}
+
+// validate checks that value is allowed for the option and returns it with
+// list elements trimmed.
+func (o Option) validate(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	values := []string{value}
+	if o.List {
+		values = splitOptionList(value)
+	}
+
+	for _, v := range values {
+		if len(o.Values) > 0 && !containsString(o.Values, v) {
+			return "", fmt.Errorf("invalid value %q for option %s (available: %s)", v, o.Name, strings.Join(o.Values, ", "))
+		}
+	}
+	return strings.Join(values, ","), nil
+}
+
+// mergeOptions returns the options of the layers, an option in a later layer
+// replacing the one with the same name in earlier layers.
+func mergeOptions(manifests []*Manifest) []Option {
+	var options []Option
+	for _, manifest := range manifests {
+		for _, option := range manifest.Options {
+			replaced := false
+			for i := range options {
+				if options[i].Name == option.Name {
+					options[i] = option
+					replaced = true
+				}
+			}
+			if !replaced {
+				options = append(options, option)
+			}
+		}
+	}
+	return options
+}
+
+// ValidateOptions checks that every value names one of options and is
+// allowed by it.
+func ValidateOptions(options []Option, values map[string]string) error {
+	_, _, err := resolveOptions(options, values, nil)
+	return err
+}
+
+// resolveOptions returns the value of every option, applying defaults, and
+// the features with those enabled by options added.
+func resolveOptions(options []Option, values map[string]string, features []string) (map[string]string, []string, error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !hasOption(options, name) {
+			var known []string
+			for _, option := range options {
+				known = append(known, option.Name)
+			}
+			if len(known) == 0 {
+				return nil, nil, fmt.Errorf("unknown option %s: the project type has no options", name)
+			}
+			return nil, nil, fmt.Errorf("unknown option %s (available: %s)", name, strings.Join(known, ", "))
+		}
+	}
+
+	resolved := make(map[string]string, len(options))
+	features = append([]string(nil), features...)
+	for _, option := range options {
+		value, set := values[option.Name]
+		switch {
+		case !set && (option.Feature == "" || containsString(features, option.Feature)):
+			value = option.Default
+		case set && value != "" && option.Feature != "" && !containsString(features, option.Feature):
+			features = append(features, option.Feature)
+		}
+
+		value, err := option.validate(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		resolved[option.Name] = value
+	}
+	return resolved, features, nil
+}
+
+func hasOption(options []Option, name string) bool {
+	for _, option := range options {
+		if option.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// splitOptionList splits a comma separated list, dropping empty elements.
+func splitOptionList(value string) []string {
+	var list []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
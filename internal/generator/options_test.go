@@ -0,0 +1,394 @@
+package generator
+
+import (
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestResolveOptions(t *testing.T) {
+	options := []Option{
+		{Name: "db", Values: []string{"postgres", "mysql"}, Default: "postgres", Feature: "database"},
+		{Name: "middleware", Values: []string{"logging", "cors", "gzip"}, Default: "logging,cors", List: true},
+		{Name: "name"},
+	}
+
+	tests := []struct {
+		name         string
+		values       map[string]string
+		features     []string
+		want         map[string]string
+		wantFeatures []string
+		wantErr      string
+	}{
+		{
+			name: "defaults",
+			want: map[string]string{"db": "", "middleware": "logging,cors", "name": ""},
+		},
+		{
+			name:         "feature selects the default",
+			features:     []string{"database"},
+			want:         map[string]string{"db": "postgres", "middleware": "logging,cors", "name": ""},
+			wantFeatures: []string{"database"},
+		},
+		{
+			name:         "option enables the feature",
+			values:       map[string]string{"db": "mysql", "middleware": " gzip , cors,", "name": "anything"},
+			features:     []string{"docker"},
+			want:         map[string]string{"db": "mysql", "middleware": "gzip,cors", "name": "anything"},
+			wantFeatures: []string{"docker", "database"},
+		},
+		{
+			name:         "empty value turns the option off",
+			values:       map[string]string{"db": "", "middleware": ""},
+			features:     []string{"database"},
+			want:         map[string]string{"db": "", "middleware": "", "name": ""},
+			wantFeatures: []string{"database"},
+		},
+		{
+			name:    "invalid value",
+			values:  map[string]string{"db": "oracle"},
+			wantErr: `invalid value "oracle" for option db`,
+		},
+		{
+			name:    "invalid list element",
+			values:  map[string]string{"middleware": "logging,tracing"},
+			wantErr: `invalid value "tracing" for option middleware`,
+		},
+		{
+			name:    "unknown option",
+			values:  map[string]string{"router": "chi"},
+			wantErr: "unknown option router (available: db, middleware, name)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotFeatures, err := resolveOptions(options, tt.values, tt.features)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolveOptions() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveOptions() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveOptions() = %v, want %v", got, tt.want)
+			}
+			if len(gotFeatures) != len(tt.wantFeatures) || (len(gotFeatures) > 0 && !reflect.DeepEqual(gotFeatures, tt.wantFeatures)) {
+				t.Errorf("resolveOptions() features = %v, want %v", gotFeatures, tt.wantFeatures)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateWebDatabase(t *testing.T) {
+	databaseFiles := []string{
+		"pkg/database/database.go",
+		"pkg/database/database_test.go",
+		"scripts/migrations/0001_create_items.up.sql",
+		"scripts/migrations/0001_create_items.down.sql",
+		"internal/models/item.go",
+		"internal/services/items.go",
+		"internal/services/items_test.go",
+		"internal/handlers/items.go",
+		"internal/handlers/items_test.go",
+	}
+
+	tests := []struct {
+		name      string
+		options   map[string]string
+		features  []string
+		wantFiles bool
+		wantMod   []string
+		wantSQL   string
+		wantYAML  string
+	}{
+		{
+			name:     "without database",
+			wantYAML: "  host: localhost\n  port: 5432\n",
+		},
+		{
+			name:      "database feature",
+			features:  []string{"database"},
+			wantFiles: true,
+			wantMod:   []string{"github.com/jackc/pgx/v5", "modernc.org/sqlite"},
+			wantSQL:   "BIGSERIAL",
+			wantYAML:  "  driver: pgx\n",
+		},
+		{
+			name:      "mysql",
+			options:   map[string]string{"db": "mysql"},
+			wantFiles: true,
+			wantMod:   []string{"github.com/go-sql-driver/mysql", "modernc.org/sqlite"},
+			wantSQL:   "AUTO_INCREMENT",
+			wantYAML:  "  driver: mysql\n",
+		},
+		{
+			name:      "sqlite",
+			options:   map[string]string{"db": "sqlite"},
+			wantFiles: true,
+			wantMod:   []string{"gopkg.in/yaml.v3", "modernc.org/sqlite"},
+			wantSQL:   "AUTOINCREMENT",
+			wantYAML:  "  driver: sqlite\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := generateWeb(t, tt.options, tt.features)
+
+			for _, file := range databaseFiles {
+				_, err := fs.Stat(out, file)
+				if tt.wantFiles && err != nil {
+					t.Errorf("Expected file %s was not created", file)
+				}
+				if !tt.wantFiles && err == nil {
+					t.Errorf("Unexpected file %s was created", file)
+				}
+			}
+
+			checkFormatted(t, out, append([]string{"main.go", "main_test.go", "pkg/config/config.go", "pkg/config/config_test.go"}, databaseFiles...))
+
+			goMod, _ := out.ReadFile("go.mod")
+			for _, mod := range tt.wantMod {
+				if !strings.Contains(string(goMod), "\t"+mod+" ") {
+					t.Errorf("go.mod does not require %s:\n%s", mod, goMod)
+				}
+			}
+			if tt.wantSQL != "" {
+				migration, _ := out.ReadFile("scripts/migrations/0001_create_items.up.sql")
+				if !strings.Contains(string(migration), tt.wantSQL) {
+					t.Errorf("migration does not contain %s:\n%s", tt.wantSQL, migration)
+				}
+			}
+			config, _ := out.ReadFile("configs/config.yaml")
+			if !strings.Contains(string(config), tt.wantYAML) {
+				t.Errorf("configs/config.yaml does not contain %q:\n%s", tt.wantYAML, config)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateWebRouter(t *testing.T) {
+	tests := []struct {
+		router  string
+		db      string
+		wantMod string
+		want    string
+	}{
+		{router: "", want: `mux.HandleFunc("GET /health", HealthHandler)`},
+		{router: "stdlib", db: "sqlite", want: `mux.HandleFunc("GET /api/v1/items/{id}", items.Get)`},
+		{router: "chi", wantMod: "github.com/go-chi/chi/v5", want: `r.Get("/health", HealthHandler)`},
+		{router: "chi", db: "sqlite", wantMod: "github.com/go-chi/chi/v5", want: `r.Get("/items/{id}", items.Get)`},
+		{router: "gin", wantMod: "github.com/gin-gonic/gin", want: `r.GET("/health", HealthHandler)`},
+		{router: "gin", db: "sqlite", wantMod: "github.com/gin-gonic/gin", want: `api.GET("/items/:id", items.Get)`},
+		{router: "echo", wantMod: "github.com/labstack/echo/v4", want: `e.GET("/health", HealthHandler)`},
+		{router: "echo", db: "sqlite", wantMod: "github.com/labstack/echo/v4", want: `api.GET("/items/:id", items.Get)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.router+" "+tt.db, func(t *testing.T) {
+			options := map[string]string{"db": tt.db}
+			if tt.router != "" {
+				options["router"] = tt.router
+			}
+			out := generateWeb(t, options, nil)
+
+			routes, _ := out.ReadFile("internal/handlers/routes.go")
+			if !strings.Contains(string(routes), tt.want) {
+				t.Errorf("routes.go does not contain %s:\n%s", tt.want, routes)
+			}
+			goMod, _ := out.ReadFile("go.mod")
+			for _, mod := range []string{"github.com/go-chi/chi/v5", "github.com/gin-gonic/gin", "github.com/labstack/echo/v4"} {
+				if got := strings.Contains(string(goMod), "\t"+mod+" "); got != (mod == tt.wantMod) {
+					t.Errorf("go.mod requires %s = %v, want %v:\n%s", mod, got, !got, goMod)
+				}
+			}
+			checkFormatted(t, out, []string{
+				"main.go",
+				"internal/handlers/handlers.go",
+				"internal/handlers/handlers_test.go",
+				"internal/handlers/routes.go",
+				"internal/handlers/items.go",
+				"internal/handlers/items_test.go",
+			})
+		})
+	}
+}
+
+func TestGenerator_GenerateWebMiddleware(t *testing.T) {
+	all := []string{"requestid", "logging", "recovery", "security", "cors", "ratelimit", "gzip", "timeout"}
+	tests := []struct {
+		middleware string
+		db         string
+		want       []string
+		wantConfig []string
+	}{
+		{middleware: "", want: nil},
+		{middleware: "logging,cors", want: []string{"logging", "cors"}, wantConfig: []string{"cors:"}},
+		{middleware: "gzip,recovery", db: "sqlite", want: []string{"recovery", "gzip"}},
+		{middleware: strings.Join(all, ","), db: "postgres", want: all, wantConfig: []string{"request_timeout:", "cors:", "rate_limit:"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.middleware+" "+tt.db, func(t *testing.T) {
+			out := generateWeb(t, map[string]string{"db": tt.db, "middleware": tt.middleware}, nil)
+
+			files := []string{"main.go", "pkg/config/config.go", "pkg/config/config_test.go"}
+			for _, name := range append([]string{"middleware"}, all...) {
+				file := "internal/middleware/" + name + ".go"
+				_, err := out.ReadFile(file)
+				want := name == "middleware" && len(tt.want) > 0 || slices.Contains(tt.want, name)
+				if got := err == nil; got != want {
+					t.Errorf("%s generated = %v, want %v", file, got, want)
+				}
+				files = append(files, file, "internal/middleware/"+name+"_test.go")
+			}
+
+			main, _ := out.ReadFile("main.go")
+			if got := strings.Contains(string(main), "middleware.Chain("); got != (len(tt.want) > 0) {
+				t.Errorf("main.go chains middleware = %v, want %v:\n%s", got, !got, main)
+			}
+			config, _ := out.ReadFile("configs/config.yaml")
+			for _, want := range tt.wantConfig {
+				if !strings.Contains(string(config), want) {
+					t.Errorf("config.yaml does not contain %s:\n%s", want, config)
+				}
+			}
+			checkFormatted(t, out, files)
+		})
+	}
+}
+
+func TestGenerator_GenerateWebAuth(t *testing.T) {
+	tests := []struct {
+		auth     string
+		features []string
+		router   string
+		db       string
+		want     []string
+	}{
+		{auth: "", want: nil},
+		{features: []string{"auth"}, want: []string{"auth.go", "jwt.go"}},
+		{auth: "jwt", router: "gin", db: "sqlite", want: []string{"auth.go", "jwt.go"}},
+		{auth: "session", router: "chi", want: []string{"auth.go", "session.go"}},
+		{auth: "apikey", router: "echo", db: "postgres", want: []string{"auth.go", "apikey.go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.auth+" "+tt.router+" "+tt.db, func(t *testing.T) {
+			options := map[string]string{"db": tt.db, "router": tt.router}
+			if tt.auth != "" {
+				options["auth"] = tt.auth
+			}
+			out := generateWeb(t, options, tt.features)
+
+			files := []string{
+				"main.go",
+				"pkg/config/config.go",
+				"pkg/config/config_test.go",
+				"internal/handlers/routes.go",
+				"internal/handlers/handlers_test.go",
+				"internal/handlers/auth.go",
+				"internal/handlers/auth_test.go",
+			}
+			for _, name := range []string{"auth.go", "jwt.go", "session.go", "apikey.go"} {
+				file := "internal/auth/" + name
+				_, err := out.ReadFile(file)
+				if got, want := err == nil, slices.Contains(tt.want, name); got != want {
+					t.Errorf("%s generated = %v, want %v", file, got, want)
+				}
+				files = append(files, file, strings.TrimSuffix(file, ".go")+"_test.go")
+			}
+
+			routes, _ := out.ReadFile("internal/handlers/routes.go")
+			if got := strings.Contains(string(routes), `"/me"`) || strings.Contains(string(routes), "/api/v1/me"); got != (len(tt.want) > 0) {
+				t.Errorf("routes.go serves /api/v1/me = %v, want %v:\n%s", got, !got, routes)
+			}
+			goMod, _ := out.ReadFile("go.mod")
+			if got := strings.Contains(string(goMod), "github.com/golang-jwt/jwt/v5"); got != slices.Contains(tt.want, "jwt.go") {
+				t.Errorf("go.mod requires golang-jwt = %v, want %v:\n%s", got, !got, goMod)
+			}
+			checkFormatted(t, out, files)
+		})
+	}
+}
+
+func TestGenerator_GenerateWebVerify(t *testing.T) {
+	spec := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(spec, []byte(testOpenAPI), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every router and authentication once, with the middleware and
+	// databases spread over them.
+	tests := []struct {
+		name     string
+		options  map[string]string
+		features []string
+	}{
+		{"defaults", nil, nil},
+		{"stdlib", map[string]string{"router": "stdlib", "db": "sqlite", "auth": "jwt", "middleware": "requestid,logging,recovery,security,cors,ratelimit,gzip,timeout"}, nil},
+		{"chi", map[string]string{"router": "chi", "db": "sqlite", "auth": "session", "openapi": spec}, nil},
+		{"gin", map[string]string{"router": "gin", "auth": "apikey", "middleware": "requestid,recovery,gzip"}, nil},
+		{"echo", map[string]string{"router": "echo", "db": "mysql", "middleware": "security,ratelimit,timeout"}, []string{"auth"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generateVerified(t, ProjectConfig{
+				ProjectName: "svc",
+				ProjectPath: filepath.Join(t.TempDir(), "svc"),
+				ProjectType: "web",
+				ModulePath:  "example.com/svc",
+				Features:    tt.features,
+				Options:     tt.options,
+			})
+		})
+	}
+}
+
+// generateWeb generates a web service named svc with the given options and
+// features into memory.
+func generateWeb(t *testing.T, options map[string]string, features []string) *MemFS {
+	t.Helper()
+
+	out := NewMemFS()
+	gen := New(ProjectConfig{
+		ProjectName: "svc",
+		ProjectPath: "svc",
+		ProjectType: "web",
+		ModulePath:  "example.com/svc",
+		Features:    features,
+		Options:     options,
+		Output:      out,
+	})
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	return out
+}
+
+// checkFormatted reports the Go files in files that out has and that are not
+// gofmt formatted.
+func checkFormatted(t *testing.T, out *MemFS, files []string) {
+	t.Helper()
+
+	for _, file := range files {
+		src, err := out.ReadFile(file)
+		if err != nil || !strings.HasSuffix(file, ".go") {
+			continue
+		}
+		if formatted, err := format.Source(src); err != nil || string(formatted) != string(src) {
+			t.Errorf("%s is not gofmt formatted: %v", file, err)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package generator
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing/fstest"
+	"time"
+)
+
+// FS is a file system generated projects are written to. Paths are slash
+// separated and relative to the project root. Directories are created before
+// the files in them.
+type FS interface {
+	MkdirAll(path string, perm fs.FileMode) error
+	WriteFile(path string, data []byte, perm fs.FileMode) error
+}
+
+// DiskFS writes to the directory Dir on disk.
+type DiskFS struct {
+	Dir string
+}
+
+func (d DiskFS) MkdirAll(name string, perm fs.FileMode) error {
+	return os.MkdirAll(filepath.Join(d.Dir, filepath.FromSlash(name)), perm)
+}
+
+// WriteFile writes the file and applies perm even if the file already
+// existed. A failing chmod is reported as an *fs.PathError with Op "chmod"
+// after the content has been written.
+func (d DiskFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	fullPath := filepath.Join(d.Dir, filepath.FromSlash(name))
+	if err := os.WriteFile(fullPath, data, perm); err != nil {
+		return err
+	}
+	// WriteFile only applies perm to new files.
+	return os.Chmod(fullPath, perm)
+}
+
+// isChmodError reports whether err is a failed chmod from DiskFS.WriteFile.
+func isChmodError(err error) bool {
+	var pathErr *fs.PathError
+	return errors.As(err, &pathErr) && pathErr.Op == "chmod"
+}
+
+// MemFS is an in-memory file tree. It is also an fs.FS, so generated projects
+// can be inspected with the io/fs functions without touching the disk.
+type MemFS struct {
+	mu    sync.Mutex
+	files fstest.MapFS
+}
+
+// NewMemFS returns an empty in-memory file tree.
+func NewMemFS() *MemFS {
+	return &MemFS{files: make(fstest.MapFS)}
+}
+
+func (m *MemFS) MkdirAll(name string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name = path.Clean(name); name != "." && name != "/"; name = path.Dir(name) {
+		if file, ok := m.files[name]; ok {
+			if !file.Mode.IsDir() {
+				return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrExist}
+			}
+			break
+		}
+		m.files[name] = &fstest.MapFile{Mode: fs.ModeDir | perm, ModTime: time.Now()}
+	}
+	return nil
+}
+
+func (m *MemFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = path.Clean(name)
+	if file, ok := m.files[name]; ok && file.Mode.IsDir() {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrExist}
+	}
+	m.files[name] = &fstest.MapFile{Data: append([]byte(nil), data...), Mode: perm, ModTime: time.Now()}
+	return nil
+}
+
+// Open implements fs.FS.
+func (m *MemFS) Open(name string) (fs.File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.files.Open(name)
+}
+
+// ReadFile implements fs.ReadFileFS.
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.files.ReadFile(name)
+}
+
+// Paths returns the paths of all files, without directories, sorted.
+func (m *MemFS) Paths() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var paths []string
+	for name, file := range m.files {
+		if !file.Mode.IsDir() {
+			paths = append(paths, name)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// archiveDirs tracks the directories written to an archive so that every
+// directory gets exactly one entry.
+type archiveDirs map[string]bool
+
+// add marks the archive directory name and its parents as written and
+// returns the ones that were not, parents first.
+func (a archiveDirs) add(name string) []string {
+	var added []string
+	for name = path.Clean(name); name != "." && name != "/" && !a[name]; name = path.Dir(name) {
+		a[name] = true
+		added = append([]string{name}, added...)
+	}
+	return added
+}
+
+// TarGzFS streams the written files as a gzip compressed tar archive, with
+// every entry below a root directory. Close must be called to complete the
+// archive.
+type TarGzFS struct {
+	root    string
+	modTime time.Time
+	dirs    archiveDirs
+	gz      *gzip.Writer
+	tw      *tar.Writer
+}
+
+// NewTarGzFS returns a TarGzFS writing to w, with entries below root. An
+// empty root places them at the top of the archive.
+func NewTarGzFS(w io.Writer, root string) *TarGzFS {
+	gz := gzip.NewWriter(w)
+	return &TarGzFS{root: root, modTime: time.Now(), dirs: make(archiveDirs), gz: gz, tw: tar.NewWriter(gz)}
+}
+
+func (t *TarGzFS) MkdirAll(name string, perm fs.FileMode) error {
+	for _, dir := range t.dirs.add(archivePath(t.root, name)) {
+		err := t.tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeDir,
+			Name:     dir + "/",
+			Mode:     int64(perm.Perm()),
+			ModTime:  t.modTime,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TarGzFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if err := t.MkdirAll(path.Dir(name), 0755); err != nil {
+		return err
+	}
+	err := t.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     archivePath(t.root, name),
+		Mode:     int64(perm.Perm()),
+		Size:     int64(len(data)),
+		ModTime:  t.modTime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = t.tw.Write(data)
+	return err
+}
+
+// Close completes the archive. It does not close the underlying writer.
+func (t *TarGzFS) Close() error {
+	if err := t.tw.Close(); err != nil {
+		return err
+	}
+	return t.gz.Close()
+}
+
+// ZipFS streams the written files as a zip archive, with every entry below a
+// root directory. Close must be called to complete the archive.
+type ZipFS struct {
+	root    string
+	modTime time.Time
+	dirs    archiveDirs
+	zw      *zip.Writer
+}
+
+// NewZipFS returns a ZipFS writing to w, with entries below root. An empty
+// root places them at the top of the archive.
+func NewZipFS(w io.Writer, root string) *ZipFS {
+	return &ZipFS{root: root, modTime: time.Now(), dirs: make(archiveDirs), zw: zip.NewWriter(w)}
+}
+
+func (z *ZipFS) MkdirAll(name string, perm fs.FileMode) error {
+	for _, dir := range z.dirs.add(archivePath(z.root, name)) {
+		header := &zip.FileHeader{Name: dir + "/", Modified: z.modTime}
+		header.SetMode(fs.ModeDir | perm)
+		if _, err := z.zw.CreateHeader(header); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (z *ZipFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if err := z.MkdirAll(path.Dir(name), 0755); err != nil {
+		return err
+	}
+	header := &zip.FileHeader{Name: archivePath(z.root, name), Method: zip.Deflate, Modified: z.modTime}
+	header.SetMode(perm)
+	w, err := z.zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Close completes the archive. It does not close the underlying writer.
+func (z *ZipFS) Close() error {
+	return z.zw.Close()
+}
+
+// archivePath returns the name of the archive entry for name below root.
+func archivePath(root, name string) string {
+	return path.Join(root, path.Clean(name))
+}
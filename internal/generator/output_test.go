@@ -0,0 +1,150 @@
+package generator
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// generateTo generates a cli project named my-cli into out.
+func generateTo(t *testing.T, out FS) {
+	t.Helper()
+
+	projectPath := filepath.Join(t.TempDir(), "my-cli")
+	config := ProjectConfig{
+		ProjectName: "my-cli",
+		ProjectPath: projectPath,
+		ProjectType: "cli",
+		ModulePath:  "example.com/my-cli",
+		Output:      out,
+	}
+	if err := New(config).Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, err := os.Stat(projectPath); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Generate() with Output touched %s: %v", projectPath, err)
+	}
+}
+
+// cliFiles are some of the files of a generated cli project.
+var cliFiles = []string{"cmd/root.go", "go.mod", "main.go"}
+
+func TestGenerator_GenerateMemFS(t *testing.T) {
+	out := NewMemFS()
+	generateTo(t, out)
+
+	var walked []string
+	err := fs.WalkDir(out, ".", func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			walked = append(walked, path)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WalkDir() error = %v", err)
+	}
+	if got, want := strings.Join(walked, ","), strings.Join(out.Paths(), ","); got != want {
+		t.Errorf("WalkDir() = %s, Paths() = %s", got, want)
+	}
+
+	for _, file := range cliFiles {
+		if _, err := fs.Stat(out, file); err != nil {
+			t.Errorf("Stat(%s) error = %v", file, err)
+		}
+	}
+	goMod, err := out.ReadFile("go.mod")
+	if err != nil || !strings.HasPrefix(string(goMod), "module example.com/my-cli\n") {
+		t.Errorf("ReadFile(go.mod) = %q, %v, want module example.com/my-cli", goMod, err)
+	}
+}
+
+func TestGenerator_GenerateTarGz(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewTarGzFS(&buf, "my-cli")
+	generateTo(t, out)
+	if err := out.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	gz, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	entries := make(map[string]bool)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entries[header.Name] {
+			t.Errorf("duplicate entry %s", header.Name)
+		}
+		entries[header.Name] = true
+	}
+
+	checkArchiveEntries(t, entries)
+}
+
+func TestGenerator_GenerateZip(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewZipFS(&buf, "my-cli")
+	generateTo(t, out)
+	if err := out.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := make(map[string]bool)
+	for _, file := range zr.File {
+		if entries[file.Name] {
+			t.Errorf("duplicate entry %s", file.Name)
+		}
+		entries[file.Name] = true
+	}
+
+	checkArchiveEntries(t, entries)
+}
+
+func checkArchiveEntries(t *testing.T, entries map[string]bool) {
+	t.Helper()
+
+	for _, want := range []string{"my-cli/", "my-cli/cmd/", "my-cli/cmd/root.go", "my-cli/go.mod"} {
+		if !entries[want] {
+			names := make([]string, 0, len(entries))
+			for name := range entries {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			t.Errorf("archive lacks %s, has %v", want, names)
+		}
+	}
+}
+
+func TestGenerator_GenerateOutputVerify(t *testing.T) {
+	config := ProjectConfig{
+		ProjectName: "my-cli",
+		ProjectPath: filepath.Join(t.TempDir(), "my-cli"),
+		ProjectType: "cli",
+		Output:      NewMemFS(),
+		Verify:      true,
+	}
+	if err := New(config).Generate(); err == nil {
+		t.Error("Generate() with Output and Verify error = nil, want error")
+	}
+}
@@ -0,0 +1,164 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// OpKind is the kind of a file system operation.
+type OpKind string
+
+const (
+	// OpDir creates a directory.
+	OpDir OpKind = "dir"
+	// OpFile writes a file.
+	OpFile OpKind = "file"
+	// OpKeep leaves an existing file untouched.
+	OpKeep OpKind = "keep"
+)
+
+// Operation is a file system change made, or planned in dry-run mode, by the
+// generator. Paths are slash separated and relative to the project path.
+type Operation struct {
+	Kind    OpKind
+	Path    string
+	Mode    fs.FileMode
+	Content string
+}
+
+// Operations returns the operations performed by the last call to Generate,
+// in order.
+func (g *Generator) Operations() []Operation {
+	return append([]Operation(nil), g.ops...)
+}
+
+func (g *Generator) record(op Operation) {
+	g.ops = append(g.ops, op)
+}
+
+type planNode struct {
+	isDir    bool
+	kept     bool
+	size     int
+	children map[string]*planNode
+}
+
+func newPlanDir() *planNode {
+	return &planNode{isDir: true, children: make(map[string]*planNode)}
+}
+
+// WritePlan writes the file tree produced by ops to w, rooted at root, with
+// the size of every file. When contents is set the content of every file is
+// written after the tree.
+func WritePlan(w io.Writer, root string, ops []Operation, contents bool) error {
+	tree := newPlanDir()
+	fileContents := make(map[string]string)
+
+	for _, op := range ops {
+		node := tree
+		elems := strings.Split(path.Clean(op.Path), "/")
+		for _, elem := range elems[:len(elems)-1] {
+			child, ok := node.children[elem]
+			if !ok {
+				child = newPlanDir()
+				node.children[elem] = child
+			}
+			node = child
+		}
+
+		last := elems[len(elems)-1]
+		switch op.Kind {
+		case OpDir:
+			if _, ok := node.children[last]; !ok {
+				node.children[last] = newPlanDir()
+			}
+		case OpFile:
+			node.children[last] = &planNode{size: len(op.Content)}
+			fileContents[op.Path] = op.Content
+		case OpKeep:
+			node.children[last] = &planNode{kept: true}
+		}
+	}
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "%s/\n", strings.TrimSuffix(root, "/"))
+	writePlanNode(b, tree, "")
+
+	dirs, files, size := countPlan(tree)
+	fmt.Fprintf(b, "\n%d directories, %d files, %s\n", dirs, files, formatSize(size))
+
+	if contents {
+		paths := make([]string, 0, len(fileContents))
+		for p := range fileContents {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+
+		for _, p := range paths {
+			fmt.Fprintf(b, "\n==> %s <==\n%s", p, fileContents[p])
+			if !strings.HasSuffix(fileContents[p], "\n") {
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writePlanNode(b *strings.Builder, node *planNode, prefix string) {
+	names := make([]string, 0, len(node.children))
+	for name := range node.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		child := node.children[name]
+		branch, indent := "├── ", "│   "
+		if i == len(names)-1 {
+			branch, indent = "└── ", "    "
+		}
+
+		switch {
+		case child.isDir:
+			fmt.Fprintf(b, "%s%s%s/\n", prefix, branch, name)
+			writePlanNode(b, child, prefix+indent)
+		case child.kept:
+			fmt.Fprintf(b, "%s%s%s (existing, kept)\n", prefix, branch, name)
+		default:
+			fmt.Fprintf(b, "%s%s%s (%s)\n", prefix, branch, name, formatSize(child.size))
+		}
+	}
+}
+
+// countPlan returns the number of directories and files below node and the
+// total size of the files.
+func countPlan(node *planNode) (dirs, files, size int) {
+	for _, child := range node.children {
+		if child.kept {
+			continue
+		}
+		if !child.isDir {
+			files++
+			size += child.size
+			continue
+		}
+		d, f, s := countPlan(child)
+		dirs += d + 1
+		files += f
+		size += s
+	}
+	return dirs, files, size
+}
+
+func formatSize(size int) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f KiB", float64(size)/1024)
+}
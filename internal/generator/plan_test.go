@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerator_GenerateDryRun(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "svc")
+	gen := New(ProjectConfig{
+		ProjectName: "svc",
+		ProjectPath: projectPath,
+		ProjectType: "microservice",
+		DryRun:      true,
+		Verify:      true,
+	})
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if _, err := os.Stat(projectPath); !os.IsNotExist(err) {
+		t.Errorf("Dry run created the project directory")
+	}
+
+	ops := make(map[string]Operation)
+	for _, op := range gen.Operations() {
+		ops[op.Path] = op
+	}
+	if op := ops["scripts/proto-gen.sh"]; op.Kind != OpFile || op.Mode != 0755 {
+		t.Errorf("scripts/proto-gen.sh operation = %+v, want executable file", op)
+	}
+	if op := ops["main.go"]; !strings.Contains(op.Content, "grpc.NewServer()") {
+		t.Errorf("main.go operation has no rendered content: %+v", op)
+	}
+	if op := ops["pkg/client"]; op.Kind != OpDir {
+		t.Errorf("pkg/client operation = %+v, want directory", op)
+	}
+}
+
+func TestWritePlan(t *testing.T) {
+	ops := []Operation{
+		{Kind: OpDir, Path: "configs"},
+		{Kind: OpDir, Path: "internal/models"},
+		{Kind: OpFile, Path: "main.go", Content: "package main\n"},
+		{Kind: OpFile, Path: "internal/handlers/handlers.go", Content: "package handlers\n"},
+		{Kind: OpFile, Path: "go.mod", Content: strings.Repeat("x", 2048)},
+	}
+
+	var b strings.Builder
+	if err := WritePlan(&b, "svc", ops, false); err != nil {
+		t.Fatalf("WritePlan() error = %v", err)
+	}
+
+	want := `svc/
+├── configs/
+├── go.mod (2.0 KiB)
+├── internal/
+│   ├── handlers/
+│   │   └── handlers.go (17 B)
+│   └── models/
+└── main.go (13 B)
+
+4 directories, 3 files, 2.0 KiB
+`
+	if b.String() != want {
+		t.Errorf("WritePlan() =\n%s\nwant\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	if err := WritePlan(&b, "svc", ops[2:4], true); err != nil {
+		t.Fatalf("WritePlan() error = %v", err)
+	}
+	if !strings.HasSuffix(b.String(), "\n==> internal/handlers/handlers.go <==\npackage handlers\n\n==> main.go <==\npackage main\n") {
+		t.Errorf("WritePlan() did not print contents:\n%s", b.String())
+	}
+}
@@ -0,0 +1,140 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/version"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFile describes a project in a YAML or JSON file so that the same
+// project can be generated reproducibly, for example:
+//
+//	name: user-api
+//	type: web
+//	module: github.com/ourorg/user-api
+//	features: [docker, ci]
+//	license: MIT
+//	author: Our Org
+//	go: "1.22"
+//	options:
+//	  db: mysql
+//	dependencies:
+//	  github.com/google/uuid: v1.6.0
+type ProjectFile struct {
+	Name     string   `yaml:"name" json:"name"`
+	Type     string   `yaml:"type" json:"type"`
+	Module   string   `yaml:"module" json:"module"`
+	Features []string `yaml:"features" json:"features"`
+	License  string   `yaml:"license" json:"license"`
+	Author   string   `yaml:"author" json:"author"`
+	// GoVersion is the Go language version declared in go.mod.
+	GoVersion string `yaml:"go" json:"go"`
+	// Dependencies maps additional module paths to the version to require.
+	// An empty version is resolved like the modules the templates import.
+	Dependencies map[string]string `yaml:"dependencies" json:"dependencies"`
+	// Options sets options of the project type. List options are comma
+	// separated.
+	Options map[string]string `yaml:"options" json:"options"`
+}
+
+// LoadProjectFile reads a project file. Files ending in .json are decoded as
+// JSON, everything else as YAML. Unknown fields are an error.
+func LoadProjectFile(path string) (*ProjectFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file ProjectFile
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&file)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid project file %s: %w", path, err)
+	}
+
+	if err := file.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid project file %s: %w", path, err)
+	}
+	return &file, nil
+}
+
+// Validate checks the values set in the project file.
+func (f *ProjectFile) Validate() error {
+	if f.Name != "" {
+		if _, err := ParseName(f.Name); err != nil {
+			return err
+		}
+	}
+	if f.Type != "" {
+		t, ok := Lookup(f.Type)
+		if !ok {
+			return fmt.Errorf("unknown project type: %s", f.Type)
+		}
+		if err := ValidateOptions(t.Options(), f.Options); err != nil {
+			return err
+		}
+	}
+	if f.Module != "" {
+		if err := ValidateModulePath(f.Module); err != nil {
+			return err
+		}
+	}
+	if err := ValidateFeatures(f.Features); err != nil {
+		return err
+	}
+	if _, err := ValidateLicense(f.License); err != nil {
+		return err
+	}
+	if err := ValidateGoVersion(f.GoVersion); err != nil {
+		return err
+	}
+	return ValidateDependencies(f.Dependencies)
+}
+
+// GoVersionPattern is the regular expression Go versions must match.
+const GoVersionPattern = `^1\.[0-9]+(\.[0-9]+)?$`
+
+var goVersionPattern = regexp.MustCompile(GoVersionPattern)
+
+// ValidateGoVersion checks that version is empty or a Go release such as
+// 1.22 or 1.22.3.
+func ValidateGoVersion(version string) error {
+	if version != "" && !goVersionPattern.MatchString(version) {
+		return fmt.Errorf("invalid Go version %q: want a release such as %s", version, DefaultGoVersion)
+	}
+	return nil
+}
+
+// compareGoVersions compares two valid Go versions and returns -1, 0 or +1
+// as a is older than, the same as or newer than b.
+func compareGoVersions(a, b string) int {
+	return version.Compare("go"+a, "go"+b)
+}
+
+// ValidateDependencies checks the module paths and versions of additional
+// dependencies. Versions may be empty to use the latest known version.
+func ValidateDependencies(deps map[string]string) error {
+	for mod, version := range deps {
+		if err := ValidateModulePath(mod); err != nil {
+			return fmt.Errorf("dependency %s: %w", mod, err)
+		}
+		if version != "" && !strings.HasPrefix(version, "v") {
+			return fmt.Errorf("dependency %s: invalid version %q: want a semantic version such as v1.2.3", mod, version)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadProjectFile(t *testing.T) {
+	want := &ProjectFile{
+		Name:         "user-api",
+		Type:         "web",
+		Module:       "github.com/ourorg/user-api",
+		Features:     []string{"docker", "ci"},
+		License:      "MIT",
+		Author:       "Our Org",
+		GoVersion:    "1.23",
+		Dependencies: map[string]string{"github.com/google/uuid": "v1.6.0"},
+		Options:      map[string]string{"db": "mysql"},
+	}
+
+	files := map[string]string{
+		"project.yaml": `name: user-api
+type: web
+module: github.com/ourorg/user-api
+features: [docker, ci]
+license: MIT
+author: Our Org
+go: "1.23"
+dependencies:
+  github.com/google/uuid: v1.6.0
+options:
+  db: mysql
+`,
+		"project.json": `{
+  "name": "user-api",
+  "type": "web",
+  "module": "github.com/ourorg/user-api",
+  "features": ["docker", "ci"],
+  "license": "MIT",
+  "author": "Our Org",
+  "go": "1.23",
+  "dependencies": {"github.com/google/uuid": "v1.6.0"},
+  "options": {"db": "mysql"}
+}`,
+	}
+
+	dir := t.TempDir()
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := LoadProjectFile(path)
+			if err != nil {
+				t.Fatalf("LoadProjectFile() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("LoadProjectFile() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestLoadProjectFileErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":   "name: svc\nrouter: chi\n",
+		"invalid name":    "name: not a name!\n",
+		"unknown type":    "type: desktop\n",
+		"unknown feature": "features: [kubernetes]\n",
+		"invalid license": "license: GPL-3.0\n",
+		"invalid go":      "go: latest\n",
+		"invalid version": "dependencies:\n  github.com/google/uuid: 1.6.0\n",
+		"invalid json":    "{",
+		"unknown option":  "type: web\noptions:\n  orm: gorm\n",
+		"invalid option":  "type: web\noptions:\n  db: oracle\n",
+	}
+
+	dir := t.TempDir()
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(name, " ", "-")+".yaml")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadProjectFile(path); err == nil {
+				t.Errorf("LoadProjectFile(%q) error = nil, want error", content)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateGoVersionAndDependencies(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "my-cli")
+	config := ProjectConfig{
+		ProjectName: "my-cli",
+		ProjectPath: projectPath,
+		ProjectType: "cli",
+		ModulePath:  "example.com/my-cli",
+		GoVersion:   "1.23",
+		Dependencies: map[string]string{
+			"github.com/spf13/cobra": "v1.8.0",
+			"github.com/google/uuid": "v1.6.0",
+		},
+	}
+	if err := New(config).Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	goMod, err := os.ReadFile(filepath.Join(projectPath, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"go 1.23\n", "github.com/google/uuid v1.6.0\n", "github.com/spf13/cobra v1.8.0\n"} {
+		if !strings.Contains(string(goMod), want) {
+			t.Errorf("go.mod = %q, want it to contain %q", goMod, want)
+		}
+	}
+
+	config.ProjectPath = filepath.Join(t.TempDir(), "my-cli")
+	config.Dependencies = map[string]string{"example.org/unknown": ""}
+	if err := New(config).Generate(); err == nil {
+		t.Error("Generate() with an unknown dependency version error = nil, want error")
+	}
+}
+
+func TestGenerator_GenerateMinimumGoVersion(t *testing.T) {
+	// Custom templates cannot lower the Go version their base needs.
+	lower := writeTemplateDir(t, map[string]string{
+		ManifestFile: `{"name": "service", "base": "web", "go": "1.18"}`,
+	})
+	higher := writeTemplateDir(t, map[string]string{
+		ManifestFile: `{"name": "service", "base": "tool", "go": "1.23"}`,
+	})
+
+	tests := []struct {
+		projectType, templateDir, goVersion string
+		wantErr                             bool
+	}{
+		{"web", "", "", false},
+		{"web", "", "1.22.3", false},
+		{"web", "", "1.21", true},
+		{"web", "", "1.21.9", true},
+		{"tool", "", "1.21", false},
+		{"web", lower, "1.21", true},
+		{"tool", higher, "1.23", false},
+		{"tool", higher, "", true},
+	}
+	for _, tt := range tests {
+		out := NewMemFS()
+		err := New(ProjectConfig{
+			ProjectName: "svc",
+			ProjectPath: "svc",
+			ProjectType: tt.projectType,
+			TemplateDir: tt.templateDir,
+			GoVersion:   tt.goVersion,
+			Output:      out,
+		}).Generate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Generate() of %s with Go %q error = %v, wantErr %v", tt.projectType, tt.goVersion, err, tt.wantErr)
+		}
+		if err != nil && len(out.Paths()) != 0 {
+			t.Errorf("Generate() of %s with Go %q wrote %v", tt.projectType, tt.goVersion, out.Paths())
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ProjectType is a kind of project the generator can scaffold. Project types
+// are made available with Register and drive both Generator.Generate and the
+// command line interface.
+type ProjectType interface {
+	// Name is the canonical name of the project type, e.g. "web".
+	Name() string
+	// Aliases are alternative names the project type can be selected by.
+	Aliases() []string
+	// Title is the display name of the project type, e.g. "Web Service".
+	Title() string
+	// Description summarizes what the project type generates.
+	Description() string
+	// Options are the settings specific to the project type.
+	Options() []Option
+	// Features are the optional features the project type supports.
+	Features() []Feature
+	// Render writes the project described by g.Config.
+	Render(g *Generator) error
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   []ProjectType
+)
+
+// Register makes a project type available by its name and aliases. It panics
+// if t is nil or any of its names is already registered.
+func Register(t ProjectType) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if t == nil {
+		panic("generator: Register project type is nil")
+	}
+	for _, name := range append([]string{t.Name()}, t.Aliases()...) {
+		if existing := lookup(name); existing != nil {
+			panic(fmt.Sprintf("generator: Register called twice for %q (already used by %s)", name, existing.Name()))
+		}
+	}
+	registry = append(registry, t)
+}
+
+// unregister removes the project type t, so that tests can register
+// project types without leaking them into later tests.
+func unregister(t ProjectType) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for i, registered := range registry {
+		if registered == t {
+			registry = append(registry[:i:i], registry[i+1:]...)
+			return
+		}
+	}
+}
+
+// Lookup returns the registered project type whose name or one of whose
+// aliases matches name, ignoring case.
+func Lookup(name string) (ProjectType, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	t := lookup(name)
+	return t, t != nil
+}
+
+func lookup(name string) ProjectType {
+	for _, t := range registry {
+		if strings.EqualFold(t.Name(), name) {
+			return t
+		}
+		for _, alias := range t.Aliases() {
+			if strings.EqualFold(alias, name) {
+				return t
+			}
+		}
+	}
+	return nil
+}
+
+// Types returns the registered project types sorted by name.
+func Types() []ProjectType {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	types := append([]ProjectType(nil), registry...)
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name() < types[j].Name()
+	})
+	return types
+}
+
+// templateType is a project type rendered from template layers.
+type templateType struct {
+	manifest *Manifest
+	options  []Option
+	features []Feature
+	layers   []fs.FS
+}
+
+// NewTemplateType returns a project type that renders the given template
+// layers in order. Its name, title, aliases and description are taken from
+// the manifest of the last layer, its options from all layers.
+func NewTemplateType(layers ...fs.FS) (ProjectType, error) {
+	if len(layers) == 0 {
+		return nil, fmt.Errorf("template project type needs at least one layer")
+	}
+
+	var manifests []*Manifest
+	for _, layer := range layers {
+		manifest, err := LoadManifest(layer)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, manifest)
+	}
+
+	manifest := manifests[len(manifests)-1]
+	if manifest.Name == "" {
+		return nil, fmt.Errorf("%s: name is required", ManifestFile)
+	}
+	features, err := supportedFeatures(manifests)
+	if err != nil {
+		return nil, err
+	}
+	return &templateType{manifest: manifest, options: mergeOptions(manifests), features: features, layers: layers}, nil
+}
+
+func (t *templateType) Name() string        { return t.manifest.Name }
+func (t *templateType) Aliases() []string   { return t.manifest.Aliases }
+func (t *templateType) Description() string { return t.manifest.Description }
+func (t *templateType) Options() []Option   { return t.options }
+func (t *templateType) Features() []Feature { return t.features }
+
+func (t *templateType) Title() string {
+	if t.manifest.Title != "" {
+		return t.manifest.Title
+	}
+	return t.manifest.Name
+}
+
+func (t *templateType) Render(g *Generator) error {
+	return g.renderLayers(t.layers)
+}
+
+// registerBuiltinTypes registers a project type for every directory under
+// templates/ except the common layer.
+func registerBuiltinTypes() {
+	entries, err := fs.ReadDir(builtinTemplates, "templates")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == commonLayer {
+			continue
+		}
+
+		layers, err := builtinLayers(entry.Name())
+		if err != nil {
+			panic(err)
+		}
+		t, err := NewTemplateType(layers...)
+		if err != nil {
+			panic(fmt.Sprintf("generator: built-in template %s: %v", path.Join("templates", entry.Name()), err))
+		}
+		Register(t)
+	}
+}
+
+func init() {
+	registerBuiltinTypes()
+}
@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   string
+		wantOK bool
+	}{
+		{name: "cli", want: "cli", wantOK: true},
+		{name: "CLI", want: "cli", wantOK: true},
+		{name: "webservice", want: "web", wantOK: true},
+		{name: "lib", want: "library", wantOK: true},
+		{name: "micro", want: "microservice", wantOK: true},
+		{name: "common", wantOK: false},
+		{name: "invalid", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Lookup(tt.name)
+			if ok != tt.wantOK {
+				t.Fatalf("Lookup() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got.Name() != tt.want {
+				t.Errorf("Lookup() = %v, want %v", got.Name(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	worker, err := NewTemplateType(fstest.MapFS{
+		ManifestFile: {Data: []byte(`{
+  "name": "worker",
+  "title": "Worker",
+  "aliases": ["job"],
+  "description": "A background worker."
+}`)},
+		"main.go.tmpl": {Data: []byte("package main // {{.ProjectTitle}} {{.ProjectName}}\n")},
+	})
+	if err != nil {
+		t.Fatalf("NewTemplateType() error = %v", err)
+	}
+	Register(worker)
+	t.Cleanup(func() { unregister(worker) })
+
+	if got, ok := Lookup("job"); !ok || got != worker {
+		t.Errorf("Lookup(job) = %v, %v, want worker", got, ok)
+	}
+
+	projectPath := filepath.Join(t.TempDir(), "jobs")
+	gen := New(ProjectConfig{
+		ProjectName: "jobs",
+		ProjectPath: projectPath,
+		ProjectType: "JOB",
+	})
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(projectPath, "main.go"))
+	if err != nil {
+		t.Fatalf("Failed to read main.go: %v", err)
+	}
+	if string(content) != "package main // Worker jobs\n" {
+		t.Errorf("main.go content = %q", content)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Register() of a duplicate alias did not panic")
+		}
+	}()
+	Register(worker)
+}
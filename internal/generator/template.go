@@ -0,0 +1,494 @@
+package generator
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ManifestFile is the name of the manifest every template directory must
+// contain at its root.
+const ManifestFile = "template.json"
+
+// DefaultGoVersion is the Go language version generated projects declare.
+const DefaultGoVersion = "1.22"
+
+const goModFile = "go.mod"
+
+// templateExt marks files that are rendered with text/template. Any other
+// file in a template directory is copied verbatim.
+const templateExt = ".tmpl"
+
+// Manifest describes a template directory.
+type Manifest struct {
+	// Name identifies the template.
+	Name string `json:"name"`
+	// Title is the display name of the project type the template produces.
+	Title string `json:"title"`
+	// License is the SPDX identifier of the license projects get when none
+	// is requested.
+	License string `json:"license"`
+	// Aliases are alternative names the project type can be selected by.
+	Aliases []string `json:"aliases"`
+	// Description is a human readable summary of what the template produces.
+	Description string `json:"description"`
+	// Directories lists directories to create even when they hold no files.
+	Directories []string `json:"directories"`
+	// Executables lists generated files that should be made executable.
+	Executables []string `json:"executables"`
+	// Dependencies pins module versions for modules imported by the
+	// template that are missing from the generator's dependency catalog.
+	Dependencies map[string]string `json:"dependencies"`
+	// Base names a built-in project type whose files are rendered first.
+	// Files in the template replace base files with the same path.
+	Base string `json:"base"`
+	// Options declares the settings specific to the project type.
+	Options []Option `json:"options"`
+	// Features lists the optional features the template supports. A
+	// template whose layers declare no features supports all of them.
+	Features []string `json:"features"`
+	// Routes lists the routes the template serves besides the operations of
+	// an OpenAPI document, as net/http patterns such as "GET /health".
+	// Entries are rendered like paths and skipped when they render to
+	// nothing. Operations colliding with them are rejected.
+	Routes []string `json:"routes"`
+	// GoVersion is the lowest Go version the generated project builds
+	// with. Generating it for an older version is an error.
+	GoVersion string `json:"go"`
+}
+
+// TemplateData is the data every template file and path is rendered with.
+type TemplateData struct {
+	// ProjectName is the project name as given, e.g. "go-validator".
+	ProjectName string
+	// PackageName is a Go package identifier, e.g. "govalidator".
+	PackageName string
+	// BinaryName is the name of the built executable, e.g. "go-validator".
+	BinaryName string
+	// DisplayName is a human readable name, e.g. "Go Validator".
+	DisplayName string
+	ProjectType string
+	// ModulePath is the module path used in go.mod and in import paths.
+	ModulePath string
+	// ProjectTitle is the display name of the project type, e.g. "Web Service".
+	ProjectTitle string
+	// GoVersion is the Go language version declared in go.mod.
+	GoVersion string
+	// Requires lists the modules imported by the generated Go files. It is
+	// only set while rendering go.mod.
+	Requires []Requirement
+	// Features lists the optional features selected for the project.
+	Features []string
+	// License is the SPDX identifier of the project license, or empty.
+	License string
+	// Author is the project author, or empty.
+	Author string
+	// Options holds the value of every option of the project type, empty
+	// for options that are off. List values are comma separated.
+	Options map[string]string
+	// API is the OpenAPI document given with an option of type
+	// OptionOpenAPI, or nil.
+	API *API
+	// Year is the current year, for copyright notices.
+	Year int
+}
+
+// HasFeature reports whether the optional feature name was selected.
+func (d TemplateData) HasFeature(name string) bool {
+	for _, f := range d.Features {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// HasOption reports whether the option name is value or, for list options,
+// contains value.
+func (d TemplateData) HasOption(name, value string) bool {
+	return containsString(splitOptionList(d.Options[name]), value)
+}
+
+// CopyrightHolder returns the author, or the project name if there is none.
+func (d TemplateData) CopyrightHolder() string {
+	if d.Author != "" {
+		return d.Author
+	}
+	return d.ProjectName
+}
+
+// LoadManifest reads and parses the manifest at the root of fsys.
+func LoadManifest(fsys fs.FS) (*Manifest, error) {
+	data, err := fs.ReadFile(fsys, ManifestFile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("template has no %s manifest", ManifestFile)
+		}
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ManifestFile, err)
+	}
+	return &manifest, nil
+}
+
+// builtinTemplates holds the scaffolds of the built-in project types. Every
+// project type is a directory under templates/ rendered on top of
+// templates/common.
+//
+//go:embed all:templates
+var builtinTemplates embed.FS
+
+const commonLayer = "common"
+
+// builtinLayers returns the template layers that make up the built-in
+// project type name.
+func builtinLayers(name string) ([]fs.FS, error) {
+	if name == "" || name == commonLayer || !fs.ValidPath(name) || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("unknown project type: %s", name)
+	}
+	if _, err := fs.Stat(builtinTemplates, path.Join("templates", name, ManifestFile)); err != nil {
+		return nil, fmt.Errorf("unknown project type: %s", name)
+	}
+
+	var layers []fs.FS
+	for _, dir := range []string{commonLayer, name} {
+		sub, err := fs.Sub(builtinTemplates, path.Join("templates", dir))
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, sub)
+	}
+	return layers, nil
+}
+
+// templateDirLayers returns the template layers for a template directory on
+// disk: the layers of its base project type, if it has one, followed by the
+// directory itself.
+func templateDirLayers(dir string) ([]fs.FS, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("template directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("template directory: %s is not a directory", dir)
+	}
+
+	fsys := os.DirFS(dir)
+	manifest, err := LoadManifest(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Base == "" {
+		return []fs.FS{fsys}, nil
+	}
+
+	layers, err := builtinLayers(manifest.Base)
+	if err != nil {
+		return nil, fmt.Errorf("template base: %w", err)
+	}
+	return append(layers, fsys), nil
+}
+
+type templateFile struct {
+	fsys fs.FS
+	name string
+}
+
+// renderLayers renders template layers into the project directory. A file
+// in a later layer replaces the file with the same path in earlier layers,
+// with or without the .tmpl extension. Paths may contain template actions,
+// files ending in .tmpl are rendered and have the extension stripped,
+// everything else is copied as is. Templates that render to nothing but
+// white space are not written.
+func (g *Generator) renderLayers(layers []fs.FS) error {
+	var (
+		title       string
+		license     string
+		minGo       string
+		directories []string
+		executables []string
+		routes      []string
+		manifests   []*Manifest
+		files       = make(map[string]templateFile)
+		catalog     = DefaultCatalog()
+	)
+
+	for _, fsys := range layers {
+		manifest, err := LoadManifest(fsys)
+		if err != nil {
+			return err
+		}
+		if manifest.Title != "" {
+			title = manifest.Title
+		}
+		if manifest.License != "" {
+			license = manifest.License
+		}
+		if manifest.GoVersion != "" {
+			if err := ValidateGoVersion(manifest.GoVersion); err != nil {
+				return fmt.Errorf("%s: %w", ManifestFile, err)
+			}
+			if minGo == "" || compareGoVersions(manifest.GoVersion, minGo) > 0 {
+				minGo = manifest.GoVersion
+			}
+		}
+		manifests = append(manifests, manifest)
+		directories = append(directories, manifest.Directories...)
+		executables = append(executables, manifest.Executables...)
+		routes = append(routes, manifest.Routes...)
+		for mod, version := range manifest.Dependencies {
+			catalog[mod] = version
+		}
+
+		err = fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || name == ManifestFile {
+				return nil
+			}
+			files[strings.TrimSuffix(name, templateExt)] = templateFile{fsys: fsys, name: name}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	data := g.templateData(title, license)
+	supported, err := supportedFeatures(manifests)
+	if err != nil {
+		return err
+	}
+	if err := checkFeatures(data.ProjectTitle, supported, g.Config.Features); err != nil {
+		return err
+	}
+	if minGo != "" && compareGoVersions(data.GoVersion, minGo) < 0 {
+		return fmt.Errorf("%s projects need Go %s or later, not %s", data.ProjectTitle, minGo, data.GoVersion)
+	}
+	options := mergeOptions(manifests)
+	data.Options, data.Features, err = resolveOptions(options, g.Config.Options, data.Features)
+	if err != nil {
+		return err
+	}
+	if data.API, err = loadOptionAPI(options, data.Options); err != nil {
+		return err
+	}
+	if data.API != nil {
+		var reserved []string
+		for _, route := range routes {
+			rendered, err := renderTemplate(route, route, data)
+			if err != nil {
+				return err
+			}
+			if rendered = strings.TrimSpace(rendered); rendered != "" {
+				reserved = append(reserved, rendered)
+			}
+		}
+		if err := data.API.checkRoutes(reserved); err != nil {
+			return fmt.Errorf("openapi: %w", err)
+		}
+	}
+
+	for _, dir := range directories {
+		target, err := renderPath(dir, data)
+		if err != nil {
+			return err
+		}
+		if err := g.createDir(target); err != nil {
+			return err
+		}
+	}
+
+	executable := make(map[string]bool)
+	for _, name := range executables {
+		target, err := renderPath(name, data)
+		if err != nil {
+			return err
+		}
+		executable[target] = true
+	}
+
+	targets := make([]string, 0, len(files))
+	for target := range files {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	// go.mod is rendered last so that its requirements can be resolved from
+	// the imports of every other generated file.
+	sources := make(map[string]string)
+	for _, name := range targets {
+		if name == goModFile {
+			continue
+		}
+
+		target, content, err := renderFile(name, files[name], data)
+		if err != nil {
+			return err
+		}
+		if strings.HasSuffix(files[name].name, templateExt) && strings.TrimSpace(content) == "" {
+			// Templates render to nothing when they do not apply, e.g. a
+			// Dockerfile without the docker feature.
+			continue
+		}
+		perm := fs.FileMode(0644)
+		if executable[target] {
+			perm = 0755
+		}
+		if err := g.writeFile(target, content, perm); err != nil {
+			return err
+		}
+		if strings.HasSuffix(target, ".go") {
+			sources[target] = content
+		}
+	}
+
+	if file, ok := files[goModFile]; ok {
+		imports, err := goImports(sources)
+		if err != nil {
+			return err
+		}
+		resolver := &Resolver{Catalog: catalog, Proxy: g.Config.GoProxy}
+		data.Requires, err = resolver.ResolveContext(g.context(), data.ModulePath, imports)
+		if err != nil {
+			return err
+		}
+		data.Requires, err = g.addDependencies(resolver, data.Requires)
+		if err != nil {
+			return err
+		}
+
+		_, content, err := renderFile(goModFile, file, data)
+		if err != nil {
+			return err
+		}
+		if err := g.createFile(goModFile, content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addDependencies adds the modules of Config.Dependencies to requires and
+// applies the versions given there, which take precedence over the catalog
+// and the module proxy.
+func (g *Generator) addDependencies(resolver *Resolver, requires []Requirement) ([]Requirement, error) {
+	required := make(map[string]bool)
+	for i, req := range requires {
+		required[req.Path] = true
+		if version := g.Config.Dependencies[req.Path]; version != "" {
+			requires[i].Version = version
+		}
+	}
+
+	for mod, version := range g.Config.Dependencies {
+		if required[mod] {
+			continue
+		}
+		if version == "" {
+			var resolved string
+			var err error
+			resolved, version, err = resolver.resolve(g.context(), mod)
+			if err != nil {
+				return nil, err
+			}
+			if resolved != mod {
+				return nil, fmt.Errorf("no version known for dependency %s; specify one", mod)
+			}
+		}
+		requires = append(requires, Requirement{Path: mod, Version: version})
+	}
+
+	sort.Slice(requires, func(i, j int) bool {
+		return requires[i].Path < requires[j].Path
+	})
+	return requires, nil
+}
+
+// renderFile renders the path and, for .tmpl files, the content of a
+// template file.
+func renderFile(name string, file templateFile, data TemplateData) (string, string, error) {
+	raw, err := fs.ReadFile(file.fsys, file.name)
+	if err != nil {
+		return "", "", err
+	}
+
+	target, err := renderPath(name, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	if !strings.HasSuffix(file.name, templateExt) {
+		return target, string(raw), nil
+	}
+	content, err := renderTemplate(target, string(raw), data)
+	if err != nil {
+		return "", "", err
+	}
+	return target, content, nil
+}
+
+// templateData returns the data templates are rendered with. title and
+// license are the defaults of the project type.
+func (g *Generator) templateData(title, license string) TemplateData {
+	if title == "" {
+		title = g.Config.ProjectType
+	}
+	switch g.Config.License {
+	case "":
+	case LicenseNone:
+		license = ""
+	default:
+		license = g.Config.License
+	}
+	modulePath := g.Config.ModulePath
+	if modulePath == "" {
+		modulePath = g.names.ModuleSegment
+	}
+	goVersion := g.Config.GoVersion
+	if goVersion == "" {
+		goVersion = DefaultGoVersion
+	}
+	return TemplateData{
+		ProjectName:  g.Config.ProjectName,
+		PackageName:  g.names.Package,
+		BinaryName:   g.names.Binary,
+		DisplayName:  g.names.Display,
+		ProjectType:  g.Config.ProjectType,
+		ModulePath:   modulePath,
+		ProjectTitle: title,
+		GoVersion:    goVersion,
+		Features:     g.Config.Features,
+		License:      license,
+		Author:       g.Config.Author,
+		Year:         time.Now().Year(),
+	}
+}
+
+// renderPath renders the template actions in a slash separated template path
+// and returns the result as a local OS path.
+func renderPath(name string, data interface{}) (string, error) {
+	rendered, err := renderTemplate(name, name, data)
+	if err != nil {
+		return "", err
+	}
+
+	rendered = path.Clean(rendered)
+	if !filepath.IsLocal(filepath.FromSlash(rendered)) {
+		return "", fmt.Errorf("template path %q renders outside the project: %q", name, rendered)
+	}
+	return filepath.FromSlash(rendered), nil
+}
@@ -0,0 +1,153 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTemplateDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create template dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write template file: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestGenerator_GenerateFromTemplateDir(t *testing.T) {
+	templateDir := writeTemplateDir(t, map[string]string{
+		ManifestFile: `{
+  "name": "service",
+  "directories": ["docs/{{.ProjectName}}"],
+  "executables": ["scripts/run.sh"]
+}`,
+		"main.go.tmpl":                     "package main // {{.ProjectName}} ({{.ProjectType}})\n",
+		"pkg/{{.ProjectName}}/doc.go.tmpl": "package {{.ProjectName}}\n",
+		"scripts/run.sh":                   "#!/bin/sh\necho {{.ProjectName}}\n",
+	})
+
+	projectPath := filepath.Join(t.TempDir(), "svc")
+	gen := New(ProjectConfig{
+		ProjectName: "svc",
+		ProjectPath: projectPath,
+		ProjectType: "web",
+		TemplateDir: templateDir,
+	})
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := map[string]string{
+		"main.go":        "package main // svc (web)\n",
+		"pkg/svc/doc.go": "package svc\n",
+		"scripts/run.sh": "#!/bin/sh\necho {{.ProjectName}}\n",
+	}
+	for file, content := range want {
+		got, err := os.ReadFile(filepath.Join(projectPath, file))
+		if err != nil {
+			t.Errorf("Failed to read %s: %v", file, err)
+			continue
+		}
+		if string(got) != content {
+			t.Errorf("%s content = %q, want %q", file, got, content)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(projectPath, ManifestFile)); !os.IsNotExist(err) {
+		t.Errorf("Manifest should not be copied into the project")
+	}
+	if info, err := os.Stat(filepath.Join(projectPath, "docs/svc")); err != nil || !info.IsDir() {
+		t.Errorf("Manifest directory docs/svc was not created")
+	}
+	if info, err := os.Stat(filepath.Join(projectPath, "scripts/run.sh")); err == nil && info.Mode()&0111 == 0 {
+		t.Errorf("scripts/run.sh is not executable")
+	}
+}
+
+func TestGenerator_GenerateFromTemplateDirWithBase(t *testing.T) {
+	templateDir := writeTemplateDir(t, map[string]string{
+		ManifestFile:   `{"name": "platform-web", "base": "web"}`,
+		"main.go.tmpl": "package main // overridden {{.ProjectName}}\n",
+		"Makefile":     "build:\n\tgo build ./...\n",
+	})
+
+	projectPath := filepath.Join(t.TempDir(), "svc")
+	gen := New(ProjectConfig{
+		ProjectName: "svc",
+		ProjectPath: projectPath,
+		ProjectType: "web",
+		TemplateDir: templateDir,
+	})
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(projectPath, "main.go"))
+	if err != nil {
+		t.Fatalf("Failed to read main.go: %v", err)
+	}
+	if string(got) != "package main // overridden svc\n" {
+		t.Errorf("main.go was not overridden, got %q", got)
+	}
+
+	for _, file := range []string{"Makefile", "internal/handlers/handlers.go", "README.md"} {
+		if _, err := os.Stat(filepath.Join(projectPath, file)); os.IsNotExist(err) {
+			t.Errorf("Expected file %s was not created", file)
+		}
+	}
+
+	readme, err := os.ReadFile(filepath.Join(projectPath, "README.md"))
+	if err != nil {
+		t.Fatalf("Failed to read README.md: %v", err)
+	}
+	if !strings.Contains(string(readme), "This is a Web Service project") {
+		t.Errorf("README.md does not use the base title:\n%s", readme)
+	}
+}
+
+func TestGenerator_GenerateFromTemplateDirErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name:  "missing manifest",
+			files: map[string]string{"main.go.tmpl": "package main\n"},
+		},
+		{
+			name:  "invalid manifest",
+			files: map[string]string{ManifestFile: "{"},
+		},
+		{
+			name:  "unknown base",
+			files: map[string]string{ManifestFile: `{"base": "common"}`},
+		},
+		{
+			name:  "path escapes project",
+			files: map[string]string{ManifestFile: `{"directories": ["{{.ProjectName}}/../.."]}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := New(ProjectConfig{
+				ProjectName: "project",
+				ProjectPath: filepath.Join(t.TempDir(), "project"),
+				ProjectType: "cli",
+				TemplateDir: writeTemplateDir(t, tt.files),
+			})
+			if err := gen.Generate(); err == nil {
+				t.Errorf("Generate() error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// verifySteps are the go commands Verify runs, in order.
+var verifySteps = [][]string{
+	{"mod", "tidy"},
+	{"vet", "./..."},
+	{"build", "./..."},
+	{"test", "./..."},
+}
+
+// VerifyError reports a verification step that failed.
+type VerifyError struct {
+	// Step is the go command that failed, e.g. "go build ./...".
+	Step string
+	// Files lists the project files the go command reported problems in.
+	Files []string
+	// Output is the combined output of the go command.
+	Output string
+}
+
+func (e *VerifyError) Error() string {
+	msg := e.Step + " failed"
+	if len(e.Files) > 0 {
+		msg += " in " + strings.Join(e.Files, ", ")
+	}
+	return msg + ":\n" + strings.TrimRight(e.Output, "\n")
+}
+
+// Verify checks that the Go project at projectPath is tidy, vets, builds and
+// passes its tests. Modules are taken from the local module cache when
+// possible so that verification works offline; go mod tidy only falls back
+// to the network when the cache lacks a dependency.
+func Verify(projectPath string) error {
+	return VerifyContext(context.Background(), projectPath)
+}
+
+// VerifyContext is like Verify but kills the running go command when ctx is
+// done.
+func VerifyContext(ctx context.Context, projectPath string) error {
+	if _, err := exec.LookPath("go"); err != nil {
+		return fmt.Errorf("verify: go command not found: %w", err)
+	}
+
+	offline := []string{"GOFLAGS=-mod=mod", "GOPROXY=off"}
+	for i, args := range verifySteps {
+		output, err := runGo(ctx, projectPath, offline, args...)
+		if err != nil && i == 0 && ctx.Err() == nil {
+			// The module cache lacks a dependency, let go mod tidy download it.
+			output, err = runGo(ctx, projectPath, []string{"GOFLAGS=-mod=mod"}, args...)
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			return &VerifyError{
+				Step:   "go " + strings.Join(args, " "),
+				Files:  offendingFiles(projectPath, output),
+				Output: output,
+			}
+		}
+	}
+	return nil
+}
+
+func runGo(ctx context.Context, dir string, env []string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err := cmd.Run()
+	return output.String(), err
+}
+
+// fileLocation matches file positions such as "./main.go:12:3:" in go
+// command output.
+var fileLocation = regexp.MustCompile(`(?m)^\s*(?:vet: )?(\S+?\.(?:go|mod)):\d+(?::\d+)?:`)
+
+// offendingFiles returns the de-duplicated project relative files mentioned
+// in go command output.
+func offendingFiles(projectPath string, output string) []string {
+	var files []string
+	seen := make(map[string]bool)
+
+	for _, match := range fileLocation.FindAllStringSubmatch(output, -1) {
+		file := match[1]
+		if filepath.IsAbs(file) {
+			rel, err := filepath.Rel(projectPath, file)
+			if err != nil || !filepath.IsLocal(rel) {
+				continue
+			}
+			file = rel
+		}
+		file = filepath.ToSlash(filepath.Clean(file))
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	return files
+}
@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func skipWithoutGo(t *testing.T) {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("skipping go toolchain run in short mode")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not installed")
+	}
+}
+
+// generateVerified generates the project described by config and verifies
+// it. The test is skipped if the dependencies of the project are missing from
+// the module cache, so that the tests pass offline.
+func generateVerified(t *testing.T, config ProjectConfig) {
+	t.Helper()
+	skipWithoutGo(t)
+
+	config.Verify = false
+	if err := New(config).Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if output, err := runGo(context.Background(), config.ProjectPath, []string{"GOFLAGS=-mod=mod", "GOPROXY=off"}, "mod", "tidy"); err != nil {
+		t.Skipf("dependencies are not in the module cache:\n%s", output)
+	}
+	if err := Verify(config.ProjectPath); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+}
+
+func TestGenerator_GenerateVerify(t *testing.T) {
+	for _, projectType := range []string{"cli", "library", "microservice", "tool", "web"} {
+		t.Run(projectType, func(t *testing.T) {
+			generateVerified(t, ProjectConfig{
+				ProjectName: "go-validator",
+				ProjectPath: filepath.Join(t.TempDir(), "go-validator"),
+				ProjectType: projectType,
+			})
+		})
+	}
+}
+
+func TestGenerator_GenerateVerifyFailure(t *testing.T) {
+	skipWithoutGo(t)
+
+	templateDir := writeTemplateDir(t, map[string]string{
+		ManifestFile:   `{"name": "broken", "base": "tool"}`,
+		"main.go.tmpl": "package main\n\nfunc main() {\n\tundefined()\n}\n",
+	})
+
+	for _, rollback := range []bool{false, true} {
+		projectPath := filepath.Join(t.TempDir(), "broken")
+		gen := New(ProjectConfig{
+			ProjectName: "broken",
+			ProjectPath: projectPath,
+			ProjectType: "tool",
+			TemplateDir: templateDir,
+			Verify:      true,
+			Rollback:    rollback,
+		})
+
+		err := gen.Generate()
+		var verifyErr *VerifyError
+		if !errors.As(err, &verifyErr) {
+			t.Fatalf("Generate() error = %v, want *VerifyError", err)
+		}
+		if !reflect.DeepEqual(verifyErr.Files, []string{"main.go"}) {
+			t.Errorf("VerifyError.Files = %v, want [main.go]\n%s", verifyErr.Files, verifyErr.Output)
+		}
+
+		_, statErr := os.Stat(projectPath)
+		if rollback != os.IsNotExist(statErr) {
+			t.Errorf("rollback = %v, project exists = %v", rollback, statErr == nil)
+		}
+	}
+}
+
+func TestOffendingFiles(t *testing.T) {
+	output := `# example.com/svc/pkg/test-project
+pkg/test-project/test-project.go:1:13: syntax error: unexpected -
+./main.go:4:2: undefined: undefined
+vet: examples/example.go:7:2: could not import
+/project/internal/x.go:3: bad
+/elsewhere/y.go:3: bad
+main.go:9:1: another
+`
+	want := []string{"pkg/test-project/test-project.go", "main.go", "examples/example.go", "internal/x.go"}
+	if got := offendingFiles("/project", output); !reflect.DeepEqual(got, want) {
+		t.Errorf("offendingFiles() = %v, want %v", got, want)
+	}
+}
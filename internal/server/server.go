@@ -0,0 +1,260 @@
+// Package server exposes project generation over HTTP.
+//
+//	GET  /types                       the project types
+//	GET  /types/{type}/schema         JSON Schema of the generate request
+//	POST /types/{type}/generate       generated project as a zip or tar.gz
+//
+// The generate request body is a JSON project file, see
+// generator.ProjectFile. The archive format is chosen with the format query
+// parameter, zip by default, or tar.gz.
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Hassani-Jr/go-project-generator/internal/generator"
+	"github.com/Hassani-Jr/go-project-generator/pkg/scaffold"
+)
+
+// maxRequestSize limits the size of generate request bodies.
+const maxRequestSize = 1 << 20
+
+// Options configure the handler.
+type Options struct {
+	// GoProxy is a GOPROXY style list of module proxies to look up the latest
+	// dependency versions instead of the built-in catalog.
+	GoProxy string
+}
+
+type server struct {
+	opts Options
+}
+
+// NewHandler returns the HTTP handler of the scaffolding API.
+func NewHandler(opts Options) http.Handler {
+	s := &server{opts: opts}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /types", s.listTypes)
+	mux.HandleFunc("GET /types/{type}/schema", s.schema)
+	mux.HandleFunc("POST /types/{type}/generate", s.generate)
+	return mux
+}
+
+// projectType is the JSON representation of a project type.
+type projectType struct {
+	Name        string   `json:"name"`
+	Aliases     []string `json:"aliases,omitempty"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+}
+
+func (s *server) listTypes(w http.ResponseWriter, r *http.Request) {
+	types := []projectType{}
+	for _, t := range scaffold.Types() {
+		types = append(types, projectType{Name: t.Name, Aliases: t.Aliases, Title: t.Title, Description: t.Description})
+	}
+	writeJSON(w, http.StatusOK, types)
+}
+
+func (s *server) schema(w http.ResponseWriter, r *http.Request) {
+	t, ok := generator.Lookup(r.PathValue("type"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown project type: %s", r.PathValue("type")))
+		return
+	}
+	writeJSON(w, http.StatusOK, projectSchema(t))
+}
+
+// projectSchema returns the JSON Schema of a generate request for t.
+func projectSchema(t generator.ProjectType) map[string]any {
+	features := []string{}
+	for _, f := range t.Features() {
+		features = append(features, f.Name)
+	}
+	licenses := append(generator.Licenses(), generator.LicenseNone)
+
+	return map[string]any{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"title":                t.Title() + " project",
+		"description":          t.Description(),
+		"type":                 "object",
+		"required":             []string{"name"},
+		"additionalProperties": false,
+		"properties": map[string]any{
+			"name": map[string]any{
+				"type":        "string",
+				"description": "Project name, e.g. user-api",
+			},
+			"type": map[string]any{
+				"type":        "string",
+				"description": "Project type, optional",
+				"enum":        append([]string{t.Name()}, t.Aliases()...),
+			},
+			"module": map[string]any{
+				"type":        "string",
+				"description": "Go module path, derived from the name by default",
+			},
+			"features": map[string]any{
+				"type":        "array",
+				"description": "Optional features",
+				"items":       map[string]any{"enum": features},
+				"uniqueItems": true,
+			},
+			"license": map[string]any{
+				"description": "License of the project, the project type's default if empty",
+				"enum":        licenses,
+			},
+			"author": map[string]any{
+				"type":        "string",
+				"description": "Copyright holder named in the license",
+			},
+			"go": map[string]any{
+				"type":        "string",
+				"description": "Go version declared in go.mod",
+				"pattern":     generator.GoVersionPattern,
+				"default":     generator.DefaultGoVersion,
+			},
+			"dependencies": map[string]any{
+				"type":                 "object",
+				"description":          "Additional modules to require, mapped to their version or an empty string for the latest known one",
+				"additionalProperties": map[string]any{"type": "string"},
+			},
+			"options": optionsSchema(t.Options()),
+		},
+	}
+}
+
+// optionsSchema returns the JSON Schema of the options of a project type.
+func optionsSchema(options []generator.Option) map[string]any {
+	properties := map[string]any{}
+	for _, option := range options {
+		if option.Type == generator.OptionOpenAPI {
+			// Documents are local files, which clients cannot name.
+			continue
+		}
+		property := map[string]any{
+			"type":        "string",
+			"description": option.Description,
+		}
+		if option.Default != "" {
+			property["default"] = option.Default
+		}
+		if len(option.Values) > 0 && option.List {
+			property["description"] = fmt.Sprintf("%s, a comma separated list of %s", option.Description, strings.Join(option.Values, ", "))
+		} else if len(option.Values) > 0 {
+			// The empty string turns the option off.
+			property["enum"] = append([]string{""}, option.Values...)
+		}
+		properties[option.Name] = property
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"description":          "Options of the project type",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+// archive is a scaffold sink producing an archive file.
+type archive interface {
+	scaffold.Sink
+	io.Closer
+}
+
+func (s *server) generate(w http.ResponseWriter, r *http.Request) {
+	t, ok := generator.Lookup(r.PathValue("type"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown project type: %s", r.PathValue("type")))
+		return
+	}
+
+	var file generator.ProjectFile
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if err := file.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if file.Name == "" {
+		writeError(w, http.StatusBadRequest, errors.New("name is required"))
+		return
+	}
+	if fileType, ok := generator.Lookup(file.Type); file.Type != "" && (!ok || fileType.Name() != t.Name()) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("type %s does not match the %s project type", file.Type, t.Name()))
+		return
+	}
+	if err := generator.ValidateOptions(t.Options(), file.Options); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	for _, option := range t.Options() {
+		if option.Type == generator.OptionOpenAPI && file.Options[option.Name] != "" {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("option %s names a local file and is not available over HTTP", option.Name))
+			return
+		}
+	}
+
+	var (
+		buf         bytes.Buffer
+		out         archive
+		contentType string
+		ext         string
+	)
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "zip":
+		out, contentType, ext = scaffold.NewZipFS(&buf, file.Name), "application/zip", ".zip"
+	case "tar.gz", "tgz":
+		out, contentType, ext = scaffold.NewTarGzFS(&buf, file.Name), "application/gzip", ".tar.gz"
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unsupported format %q: want zip or tar.gz", format))
+		return
+	}
+
+	_, err := scaffold.Generate(r.Context(), scaffold.Config{
+		Name:         file.Name,
+		Type:         t.Name(),
+		Module:       file.Module,
+		Features:     file.Features,
+		License:      file.License,
+		Author:       file.Author,
+		GoVersion:    file.GoVersion,
+		Options:      file.Options,
+		Dependencies: file.Dependencies,
+	}, scaffold.Options{Sink: out, GoProxy: s.opts.GoProxy})
+	if err == nil {
+		err = out.Close()
+	}
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.Name+ext))
+	w.Header().Set("Content-Length", fmt.Sprint(buf.Len()))
+	_, _ = buf.WriteTo(w)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": strings.TrimSpace(err.Error())})
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func do(t *testing.T, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	NewHandler(Options{}).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestListTypes(t *testing.T) {
+	rec := do(t, http.MethodGet, "/types", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+	}
+
+	var types []projectType
+	if err := json.Unmarshal(rec.Body.Bytes(), &types); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, pt := range types {
+		names = append(names, pt.Name)
+	}
+	if got := strings.Join(names, ","); got != "cli,library,microservice,tool,web" {
+		t.Errorf("types = %s, want cli,library,microservice,tool,web", got)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	rec := do(t, http.MethodGet, "/types/webservice/schema", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+	}
+
+	var schema struct {
+		Title      string                     `json:"title"`
+		Required   []string                   `json:"required"`
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &schema); err != nil {
+		t.Fatal(err)
+	}
+	if schema.Title != "Web Service project" {
+		t.Errorf("title = %q, want Web Service project", schema.Title)
+	}
+	for _, property := range []string{"name", "module", "features", "license", "author", "go", "dependencies", "options"} {
+		if _, ok := schema.Properties[property]; !ok {
+			t.Errorf("schema lacks property %s", property)
+		}
+	}
+
+	if !strings.Contains(string(schema.Properties["options"]), `"db"`) {
+		t.Errorf("options schema lacks db: %s", schema.Properties["options"])
+	}
+	if strings.Contains(string(schema.Properties["options"]), `"openapi"`) {
+		t.Errorf("options schema offers openapi: %s", schema.Properties["options"])
+	}
+
+	if !strings.Contains(string(schema.Properties["features"]), `"auth"`) {
+		t.Errorf("web features schema lacks auth: %s", schema.Properties["features"])
+	}
+	if rec := do(t, http.MethodGet, "/types/library/schema", ""); strings.Contains(rec.Body.String(), `"docker"`) {
+		t.Errorf("library schema offers docker: %s", rec.Body)
+	}
+
+	if rec := do(t, http.MethodGet, "/types/desktop/schema", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown type status = %d, want 404", rec.Code)
+	}
+}
+
+func TestGenerateZip(t *testing.T) {
+	rec := do(t, http.MethodPost, "/types/web/generate", `{"name": "user-api", "module": "github.com/ourorg/user-api", "features": ["docker"]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+	}
+	if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename="user-api.zip"` {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	for _, want := range []string{"user-api/main.go", "user-api/Dockerfile", "user-api/go.mod"} {
+		if files[want] == nil {
+			t.Errorf("archive lacks %s", want)
+		}
+	}
+
+	r, err := files["user-api/go.mod"].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	goMod, _ := io.ReadAll(r)
+	if !strings.HasPrefix(string(goMod), "module github.com/ourorg/user-api\n") {
+		t.Errorf("go.mod = %q, want module github.com/ourorg/user-api", goMod)
+	}
+}
+
+func TestGenerateTarGz(t *testing.T) {
+	rec := do(t, http.MethodPost, "/types/cli/generate?format=tar.gz", `{"name": "my-cli"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+	}
+
+	gz, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	found := false
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		found = found || header.Name == "my-cli/cmd/root.go"
+	}
+	if !found {
+		t.Error("archive lacks my-cli/cmd/root.go")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		status int
+	}{
+		{"unknown type", "/types/desktop/generate", `{"name": "app"}`, http.StatusNotFound},
+		{"invalid json", "/types/cli/generate", `{`, http.StatusBadRequest},
+		{"unknown field", "/types/cli/generate", `{"name": "app", "router": "chi"}`, http.StatusBadRequest},
+		{"missing name", "/types/cli/generate", `{}`, http.StatusBadRequest},
+		{"invalid license", "/types/cli/generate", `{"name": "app", "license": "GPL-3.0"}`, http.StatusBadRequest},
+		{"unknown option", "/types/cli/generate", `{"name": "app", "options": {"db": "mysql"}}`, http.StatusBadRequest},
+		{"invalid option", "/types/web/generate", `{"name": "app", "options": {"db": "oracle"}}`, http.StatusBadRequest},
+		{"local file option", "/types/web/generate", `{"name": "app", "options": {"openapi": "/etc/passwd"}}`, http.StatusBadRequest},
+		{"type mismatch", "/types/cli/generate", `{"name": "app", "type": "web"}`, http.StatusBadRequest},
+		{"unknown format", "/types/cli/generate?format=rar", `{"name": "app"}`, http.StatusBadRequest},
+		{"unknown dependency", "/types/cli/generate", `{"name": "app", "dependencies": {"example.org/unknown": ""}}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := do(t, http.MethodPost, tt.target, tt.body)
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			var body struct{ Error string }
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Error == "" {
+				t.Errorf("body = %s, want a JSON error", rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+// Package scaffold generates Go projects. It is the library behind the
+// go-project-generator command and renders the same built-in project types
+// and template directories, without printing anything.
+//
+//	result, err := scaffold.Generate(ctx, scaffold.Config{
+//		Name:   "user-api",
+//		Type:   "web",
+//		Dir:    "/src/user-api",
+//		Module: "github.com/ourorg/user-api",
+//	}, scaffold.Options{})
+package scaffold
+
+import (
+	"context"
+	"io"
+	"io/fs"
+
+	"github.com/Hassani-Jr/go-project-generator/internal/generator"
+)
+
+// Config describes the project to generate.
+type Config struct {
+	// Name is the project name, e.g. user-api. See the naming rules of the
+	// go-project-generator command.
+	Name string
+	// Type is the name or an alias of a project type, see Types. It is
+	// ignored when TemplateDir is set.
+	Type string
+	// Dir is the directory the project is generated in. It defaults to Name
+	// in the current directory and is not used when Options.Sink is set.
+	Dir string
+	// Module is the Go module path. It defaults to a path derived from Name.
+	Module string
+	// Features lists optional features, see Features.
+	Features []string
+	// License is a license from Licenses, LicenseNone, or empty for the
+	// project type's default.
+	License string
+	// Author is the copyright holder named in the license.
+	Author string
+	// GoVersion is the Go version declared in go.mod.
+	GoVersion string
+	// Options sets options of the project type, see ProjectType.Options.
+	// List options are comma separated.
+	Options map[string]string
+	// Dependencies maps additional modules to require to their version. An
+	// empty version uses the latest known one.
+	Dependencies map[string]string
+	// TemplateDir is a template directory to render instead of Type.
+	TemplateDir string
+}
+
+// Options control how a project is generated.
+type Options struct {
+	// Sink receives the generated directories and files instead of Dir.
+	Sink Sink
+	// DryRun only reports the files that would be generated.
+	DryRun bool
+	// Verify runs go mod tidy, vet, build and test in the generated project.
+	// It needs the project on disk and cannot be combined with Sink.
+	Verify bool
+	// Rollback removes the generated project if verification fails.
+	Rollback bool
+	// GoProxy is a GOPROXY style list of module proxies to look up the latest
+	// dependency versions instead of the built-in catalog.
+	GoProxy string
+	// OnConflict decides what happens to files that already exist in Dir.
+	OnConflict ConflictMode
+	// ResolveConflict decides about every conflicting file when OnConflict
+	// is ConflictAsk.
+	ResolveConflict ConflictResolver
+}
+
+// Sink receives a generated project. Paths are slash separated and relative
+// to the project root; directories are created before the files in them.
+type Sink interface {
+	MkdirAll(path string, perm fs.FileMode) error
+	WriteFile(path string, data []byte, perm fs.FileMode) error
+}
+
+// DiskFS is a Sink writing to the directory Dir on disk, without the
+// conflict checks and atomic staging of Config.Dir.
+type DiskFS = generator.DiskFS
+
+// MemFS is a Sink keeping the project in memory. It is also an fs.FS.
+type MemFS = generator.MemFS
+
+// NewMemFS returns an empty in-memory Sink.
+func NewMemFS() *MemFS {
+	return generator.NewMemFS()
+}
+
+// TarGzFS is a Sink streaming a gzip compressed tar archive.
+type TarGzFS = generator.TarGzFS
+
+// NewTarGzFS returns a Sink writing a tar.gz archive to w with every entry
+// below root. Close it to complete the archive.
+func NewTarGzFS(w io.Writer, root string) *TarGzFS {
+	return generator.NewTarGzFS(w, root)
+}
+
+// ZipFS is a Sink streaming a zip archive.
+type ZipFS = generator.ZipFS
+
+// NewZipFS returns a Sink writing a zip archive to w with every entry below
+// root. Close it to complete the archive.
+func NewZipFS(w io.Writer, root string) *ZipFS {
+	return generator.NewZipFS(w, root)
+}
+
+// ConflictMode decides what happens to files that already exist.
+type ConflictMode = generator.ConflictMode
+
+const (
+	// ConflictFail refuses to generate into a non-empty directory.
+	ConflictFail = generator.ConflictFail
+	// ConflictOverwrite replaces existing files.
+	ConflictOverwrite = generator.ConflictOverwrite
+	// ConflictSkip keeps existing files and only writes new ones.
+	ConflictSkip = generator.ConflictSkip
+	// ConflictAsk lets Options.ResolveConflict decide.
+	ConflictAsk = generator.ConflictAsk
+)
+
+// Conflict is a generated file that already exists with different content.
+type Conflict = generator.Conflict
+
+// ConflictResolver reports whether the existing file of a conflict should be
+// overwritten.
+type ConflictResolver = generator.ConflictResolver
+
+// VerifyError reports the verification step that failed.
+type VerifyError = generator.VerifyError
+
+// ErrProjectExists is returned when generating into a non-empty directory
+// with ConflictFail.
+var ErrProjectExists = generator.ErrProjectExists
+
+// LicenseNone disables the license a project type generates by default.
+const LicenseNone = generator.LicenseNone
+
+// Action is what happened to a generated file.
+type Action string
+
+const (
+	// ActionCreate means the file was written, or would be in a dry run.
+	ActionCreate Action = "create"
+	// ActionKeep means an existing file was left untouched.
+	ActionKeep Action = "keep"
+)
+
+// File is a generated file.
+type File struct {
+	// Path is slash separated and relative to the project root.
+	Path   string
+	Mode   fs.FileMode
+	Size   int
+	Action Action
+}
+
+// Result describes a generated project.
+type Result struct {
+	// Dir is the project directory, empty when the project went to a Sink.
+	Dir string
+	// Type is the name of the project type, empty for template directories.
+	Type string
+	// Module is the Go module path of the project.
+	Module string
+	// Directories lists the created directories, slash separated.
+	Directories []string
+	// Files lists the generated files in the order they were written.
+	Files []File
+	// Warnings lists problems that did not stop generation.
+	Warnings []string
+}
+
+// Generate generates the project described by config. On error nothing is
+// left behind in Dir, but a Sink may have received part of the project.
+func Generate(ctx context.Context, config Config, opts Options) (*Result, error) {
+	names, err := generator.ParseName(config.Name)
+	if err != nil {
+		return nil, err
+	}
+	dir := config.Dir
+	if dir == "" {
+		dir = config.Name
+	}
+	module, err := names.ModulePath(config.Module)
+	if err != nil {
+		return nil, err
+	}
+
+	projectConfig := generator.ProjectConfig{
+		ProjectName:     config.Name,
+		ProjectPath:     dir,
+		ProjectType:     config.Type,
+		ModulePath:      module,
+		Features:        config.Features,
+		License:         config.License,
+		Author:          config.Author,
+		GoVersion:       config.GoVersion,
+		Options:         config.Options,
+		Dependencies:    config.Dependencies,
+		TemplateDir:     config.TemplateDir,
+		GoProxy:         opts.GoProxy,
+		Verify:          opts.Verify,
+		Rollback:        opts.Rollback,
+		DryRun:          opts.DryRun,
+		OnConflict:      opts.OnConflict,
+		ResolveConflict: opts.ResolveConflict,
+	}
+	if opts.Sink != nil {
+		projectConfig.Output = opts.Sink
+	}
+
+	gen := generator.New(projectConfig)
+	if err := gen.GenerateContext(ctx); err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Module:   module,
+		Warnings: gen.Warnings(),
+	}
+	if opts.Sink == nil {
+		result.Dir = dir
+	}
+	if config.TemplateDir == "" {
+		result.Type = gen.Config.ProjectType
+	}
+
+	for _, op := range gen.Operations() {
+		switch op.Kind {
+		case generator.OpDir:
+			result.Directories = append(result.Directories, op.Path)
+		case generator.OpFile:
+			result.Files = append(result.Files, File{Path: op.Path, Mode: op.Mode, Size: len(op.Content), Action: ActionCreate})
+		case generator.OpKeep:
+			result.Files = append(result.Files, File{Path: op.Path, Action: ActionKeep})
+		}
+	}
+
+	return result, nil
+}
+
+// ProjectType describes a project type that can be generated.
+type ProjectType struct {
+	Name        string
+	Aliases     []string
+	Title       string
+	Description string
+	Options     []Option
+	// Features are the optional features the project type supports.
+	Features []Feature
+}
+
+// Option is a setting specific to a project type.
+type Option = generator.Option
+
+// OptionOpenAPI is the Type of options whose value is the path of an OpenAPI
+// 3 document to generate code from.
+const OptionOpenAPI = generator.OptionOpenAPI
+
+// Types returns the available project types sorted by name.
+func Types() []ProjectType {
+	var types []ProjectType
+	for _, t := range generator.Types() {
+		types = append(types, ProjectType{
+			Name:        t.Name(),
+			Aliases:     t.Aliases(),
+			Title:       t.Title(),
+			Description: t.Description(),
+			Options:     t.Options(),
+			Features:    t.Features(),
+		})
+	}
+	return types
+}
+
+// Feature is an optional part of a generated project.
+type Feature = generator.Feature
+
+// Features returns the optional features projects can be generated with.
+func Features() []Feature {
+	return generator.Features()
+}
+
+// Licenses returns the SPDX identifiers of the licenses projects can be
+// generated with.
+func Licenses() []string {
+	return generator.Licenses()
+}
@@ -0,0 +1,128 @@
+package scaffold
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// memorySink records the written files.
+type memorySink struct {
+	dirs  []string
+	files map[string]string
+}
+
+func (s *memorySink) MkdirAll(path string, perm fs.FileMode) error {
+	s.dirs = append(s.dirs, path)
+	return nil
+}
+
+func (s *memorySink) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	if s.files == nil {
+		s.files = make(map[string]string)
+	}
+	s.files[path] = string(data)
+	return nil
+}
+
+func TestGenerate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "user-api")
+
+	result, err := Generate(context.Background(), Config{
+		Name:     "user-api",
+		Type:     "webservice",
+		Dir:      dir,
+		Module:   "github.com/ourorg/user-api",
+		Features: []string{"docker"},
+	}, Options{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if result.Dir != dir || result.Type != "web" || result.Module != "github.com/ourorg/user-api" {
+		t.Errorf("Generate() = {Dir: %s, Type: %s, Module: %s}, want {%s, web, github.com/ourorg/user-api}", result.Dir, result.Type, result.Module, dir)
+	}
+
+	found := make(map[string]bool)
+	for _, file := range result.Files {
+		found[file.Path] = true
+		info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(file.Path)))
+		if err != nil {
+			t.Errorf("file %s in the result was not written: %v", file.Path, err)
+			continue
+		}
+		if int(info.Size()) != file.Size {
+			t.Errorf("file %s has %d bytes, result says %d", file.Path, info.Size(), file.Size)
+		}
+	}
+	for _, want := range []string{"main.go", "go.mod", "Dockerfile"} {
+		if !found[want] {
+			t.Errorf("Generate() files do not include %s", want)
+		}
+	}
+}
+
+func TestGenerateSink(t *testing.T) {
+	sink := &memorySink{}
+	dir := filepath.Join(t.TempDir(), "my-cli")
+
+	result, err := Generate(context.Background(), Config{Name: "my-cli", Type: "cli", Dir: dir}, Options{Sink: sink})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if _, err := os.Stat(dir); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Generate() with a sink touched %s: %v", dir, err)
+	}
+	if result.Dir != "" {
+		t.Errorf("Result.Dir = %q, want empty", result.Dir)
+	}
+	if len(sink.files) != len(result.Files) {
+		t.Errorf("sink received %d files, result lists %d", len(sink.files), len(result.Files))
+	}
+	if !strings.HasPrefix(sink.files["go.mod"], "module my-cli\n") {
+		t.Errorf("go.mod = %q, want module my-cli", sink.files["go.mod"])
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		config Config
+		opts   Options
+	}{
+		{"invalid name", context.Background(), Config{Name: "not a name!", Type: "cli"}, Options{}},
+		{"unknown type", context.Background(), Config{Name: "app", Type: "desktop"}, Options{}},
+		{"verify with sink", context.Background(), Config{Name: "app", Type: "cli"}, Options{Sink: &memorySink{}, Verify: true}},
+		{"canceled", canceled, Config{Name: "app", Type: "cli"}, Options{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Dir = filepath.Join(t.TempDir(), "app")
+			if _, err := Generate(tt.ctx, tt.config, tt.opts); err == nil {
+				t.Error("Generate() error = nil, want error")
+			}
+			if _, err := os.Stat(tt.config.Dir); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("Generate() left %s behind", tt.config.Dir)
+			}
+		})
+	}
+}
+
+func TestTypes(t *testing.T) {
+	var names []string
+	for _, projectType := range Types() {
+		names = append(names, projectType.Name)
+	}
+	if got := strings.Join(names, ","); got != "cli,library,microservice,tool,web" {
+		t.Errorf("Types() = %s, want cli,library,microservice,tool,web", got)
+	}
+}